@@ -11,10 +11,94 @@ type ReplyParam struct {
 	Content   string
 	PicInfo   string
 	VideoInfo string
+	Version   *int32 // 期望的评价版本号（乐观锁），为空时不校验
+}
+
+// UpdateReplyParam 修改回复的参数
+type UpdateReplyParam struct {
+	ReplyID   int64
+	StoreID   int64
+	Content   string
+	PicInfo   string
+	VideoInfo string
+	Version   *int32 // 期望的回复版本号（乐观锁），为空时不校验
+}
+
+// DeleteReplyParam 撤回回复的参数
+type DeleteReplyParam struct {
+	ReplyID int64
+	StoreID int64
+	Version *int32 // 期望的回复版本号（乐观锁），为空时不校验
+}
+
+// StoreSummary 店铺评分汇总
+type StoreSummary struct {
+	StoreID         int64
+	ReviewCount     int32
+	AvgScore        float64
+	AvgServiceScore float64
+	AvgExpressScore float64
+	StarCounts      map[int32]int32 // key为星级1~5，value为评价数
+	MediaRatio      float64
+	ReplyRatio      float64
+}
+
+// AppealParam 申诉评价的参数
+type AppealParam struct {
+	ReviewID  int64
+	StoreID   int64
+	Reason    string
+	Content   string
+	PicInfo   string // 申诉凭证：图片
+	VideoInfo string // 申诉凭证：视频
+	OpUser    string
+	Version   *int32 // 修改待审核的申诉时，期望的申诉版本号
+}
+
+// WithdrawAppealParam 撤回申诉的参数
+type WithdrawAppealParam struct {
+	AppealID int64
+	StoreID  int64
+	Version  *int32 // 期望的申诉版本号（乐观锁），为空时不校验
+}
+
+// ListAppealParam 查询申诉列表的参数
+type ListAppealParam struct {
+	StoreID int64
+	Status  int32 // 申诉状态，为0时查询全部
+	Page    int32
+	Size    int32
+}
+
+// AppealInfo 申诉信息，包含申诉凭证和运营的审核结果
+type AppealInfo struct {
+	AppealID    int64
+	ReviewID    int64
+	StoreID     int64
+	Status      int32
+	Reason      string
+	Content     string
+	PicInfo     string
+	VideoInfo   string
+	SubmitCount int32
+	OpReason    string
+	OpRemarks   string
+	Version     int32
+	DeadlineAt  string // 审核截止时间
+	SLAStatus   int32  // 审核时效状态：0已处理；1未超时；2已超时；3已升级
+	CreateAt    string
+	UpdateAt    string
 }
 
 type BusinessRepo interface {
 	Reply(context.Context, *ReplyParam) (int64, error)
+	UpdateReply(context.Context, *UpdateReplyParam) error
+	DeleteReply(context.Context, *DeleteReplyParam) error
+	GetStoreSummary(context.Context, int64) (*StoreSummary, error)
+	AppealReview(context.Context, *AppealParam) (*AppealInfo, error)
+	WithdrawAppeal(context.Context, *WithdrawAppealParam) error
+	GetAppeal(ctx context.Context, appealID, storeID int64) (*AppealInfo, error)
+	ListAppeals(context.Context, *ListAppealParam) ([]*AppealInfo, error)
 }
 
 type BusinessUseCase struct {
@@ -31,3 +115,45 @@ func (r *BusinessUseCase) CreateReply(ctx context.Context, param *ReplyParam) (i
 	r.log.WithContext(ctx).Infof("CreateReply: params:%v", param)
 	return r.repo.Reply(ctx, param)
 }
+
+// UpdateReply 修改回复，只能在回复后的一段时间内修改
+func (r *BusinessUseCase) UpdateReply(ctx context.Context, param *UpdateReplyParam) error {
+	r.log.WithContext(ctx).Infof("UpdateReply: params:%v", param)
+	return r.repo.UpdateReply(ctx, param)
+}
+
+// DeleteReply 撤回回复，只能在回复后的一段时间内撤回
+func (r *BusinessUseCase) DeleteReply(ctx context.Context, param *DeleteReplyParam) error {
+	r.log.WithContext(ctx).Infof("DeleteReply: params:%v", param)
+	return r.repo.DeleteReply(ctx, param)
+}
+
+// GetStoreSummary 商家查询自己店铺的评分汇总
+func (r *BusinessUseCase) GetStoreSummary(ctx context.Context, storeID int64) (*StoreSummary, error) {
+	r.log.WithContext(ctx).Infof("GetStoreSummary: storeID:%v", storeID)
+	return r.repo.GetStoreSummary(ctx, storeID)
+}
+
+// AppealReview 商家申诉评价，待审核的申诉再次提交时会修改申诉内容
+func (r *BusinessUseCase) AppealReview(ctx context.Context, param *AppealParam) (*AppealInfo, error) {
+	r.log.WithContext(ctx).Infof("AppealReview: params:%v", param)
+	return r.repo.AppealReview(ctx, param)
+}
+
+// WithdrawAppeal 商家撤回待审核的申诉
+func (r *BusinessUseCase) WithdrawAppeal(ctx context.Context, param *WithdrawAppealParam) error {
+	r.log.WithContext(ctx).Infof("WithdrawAppeal: params:%v", param)
+	return r.repo.WithdrawAppeal(ctx, param)
+}
+
+// GetAppeal 商家查询申诉详情
+func (r *BusinessUseCase) GetAppeal(ctx context.Context, appealID, storeID int64) (*AppealInfo, error) {
+	r.log.WithContext(ctx).Infof("GetAppeal: appealID:%v, storeID:%v", appealID, storeID)
+	return r.repo.GetAppeal(ctx, appealID, storeID)
+}
+
+// ListMyAppeals 商家查询自己店铺的申诉列表，可以按申诉状态过滤
+func (r *BusinessUseCase) ListMyAppeals(ctx context.Context, param *ListAppealParam) ([]*AppealInfo, error) {
+	r.log.WithContext(ctx).Infof("ListMyAppeals: params:%v", param)
+	return r.repo.ListAppeals(ctx, param)
+}
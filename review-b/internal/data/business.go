@@ -21,6 +21,7 @@ func (b *businessRepo) Reply(ctx context.Context, param *biz.ReplyParam) (int64,
 		Content:   param.Content,
 		PicInfo:   param.PicInfo,
 		VideoInfo: param.VideoInfo,
+		Version:   param.Version,
 	})
 	b.log.WithContext(ctx).Debugf("[data] Reply: ret:%v, err:%v", ret, err)
 	if err != nil {
@@ -30,6 +31,145 @@ func (b *businessRepo) Reply(ctx context.Context, param *biz.ReplyParam) (int64,
 	return ret.ReplyID, nil
 }
 
+func (b *businessRepo) UpdateReply(ctx context.Context, param *biz.UpdateReplyParam) error {
+	b.log.WithContext(ctx).Infof("[data] UpdateReply: params:%v", param)
+	ret, err := b.data.rc.UpdateReply(ctx, &v1.UpdateReplyRequest{
+		ReplyID:   param.ReplyID,
+		StoreID:   param.StoreID,
+		Content:   param.Content,
+		PicInfo:   param.PicInfo,
+		VideoInfo: param.VideoInfo,
+		Version:   param.Version,
+	})
+	b.log.WithContext(ctx).Debugf("[data] UpdateReply: ret:%v, err:%v", ret, err)
+	return err
+}
+
+func (b *businessRepo) DeleteReply(ctx context.Context, param *biz.DeleteReplyParam) error {
+	b.log.WithContext(ctx).Infof("[data] DeleteReply: params:%v", param)
+	ret, err := b.data.rc.DeleteReply(ctx, &v1.DeleteReplyRequest{
+		ReplyID: param.ReplyID,
+		StoreID: param.StoreID,
+		Version: param.Version,
+	})
+	b.log.WithContext(ctx).Debugf("[data] DeleteReply: ret:%v, err:%v", ret, err)
+	return err
+}
+
+func (b *businessRepo) GetStoreSummary(ctx context.Context, storeID int64) (*biz.StoreSummary, error) {
+	b.log.WithContext(ctx).Infof("[data] GetStoreSummary: storeID:%v", storeID)
+	ret, err := b.data.rc.GetStoreSummary(ctx, &v1.GetStoreSummaryRequest{StoreID: storeID})
+	if err != nil {
+		b.log.WithContext(ctx).Infof("[data] GetStoreSummary: err:%v", err)
+		return nil, err
+	}
+	starCounts := make(map[int32]int32, len(ret.Distribution))
+	for _, v := range ret.Distribution {
+		starCounts[v.Star] = v.Count
+	}
+	return &biz.StoreSummary{
+		StoreID:         ret.StoreID,
+		ReviewCount:     ret.ReviewCount,
+		AvgScore:        ret.AvgScore,
+		AvgServiceScore: ret.AvgServiceScore,
+		AvgExpressScore: ret.AvgExpressScore,
+		StarCounts:      starCounts,
+		MediaRatio:      ret.MediaRatio,
+		ReplyRatio:      ret.ReplyRatio,
+	}, nil
+}
+
+func (b *businessRepo) AppealReview(ctx context.Context, param *biz.AppealParam) (*biz.AppealInfo, error) {
+	b.log.WithContext(ctx).Infof("[data] AppealReview: params:%v", param)
+	ret, err := b.data.rc.AppealReview(ctx, &v1.AppealReviewRequest{
+		ReviewID:  param.ReviewID,
+		StoreID:   param.StoreID,
+		Reason:    param.Reason,
+		Content:   param.Content,
+		PicInfo:   param.PicInfo,
+		VideoInfo: param.VideoInfo,
+		OpUser:    param.OpUser,
+		Version:   param.Version,
+	})
+	b.log.WithContext(ctx).Debugf("[data] AppealReview: ret:%v, err:%v", ret, err)
+	if err != nil {
+		return nil, err
+	}
+	return &biz.AppealInfo{
+		AppealID:    ret.AppealID,
+		ReviewID:    param.ReviewID,
+		StoreID:     param.StoreID,
+		Status:      ret.Status,
+		Reason:      param.Reason,
+		Content:     param.Content,
+		PicInfo:     param.PicInfo,
+		VideoInfo:   param.VideoInfo,
+		SubmitCount: ret.SubmitCount,
+		Version:     ret.Version,
+	}, nil
+}
+
+func (b *businessRepo) WithdrawAppeal(ctx context.Context, param *biz.WithdrawAppealParam) error {
+	b.log.WithContext(ctx).Infof("[data] WithdrawAppeal: params:%v", param)
+	ret, err := b.data.rc.WithdrawAppeal(ctx, &v1.WithdrawAppealRequest{
+		AppealID: param.AppealID,
+		StoreID:  param.StoreID,
+		Version:  param.Version,
+	})
+	b.log.WithContext(ctx).Debugf("[data] WithdrawAppeal: ret:%v, err:%v", ret, err)
+	return err
+}
+
+func (b *businessRepo) GetAppeal(ctx context.Context, appealID, storeID int64) (*biz.AppealInfo, error) {
+	b.log.WithContext(ctx).Infof("[data] GetAppeal: appealID:%v, storeID:%v", appealID, storeID)
+	ret, err := b.data.rc.GetAppeal(ctx, &v1.GetAppealRequest{AppealID: appealID, StoreID: storeID})
+	if err != nil {
+		b.log.WithContext(ctx).Infof("[data] GetAppeal: err:%v", err)
+		return nil, err
+	}
+	return toAppealInfo(ret.Data), nil
+}
+
+func (b *businessRepo) ListAppeals(ctx context.Context, param *biz.ListAppealParam) ([]*biz.AppealInfo, error) {
+	b.log.WithContext(ctx).Infof("[data] ListAppeals: params:%v", param)
+	ret, err := b.data.rc.ListAppealByStoreID(ctx, &v1.ListAppealByStoreIDRequest{
+		StoreID: param.StoreID,
+		Status:  param.Status,
+		Page:    param.Page,
+		Size:    param.Size,
+	})
+	if err != nil {
+		b.log.WithContext(ctx).Infof("[data] ListAppeals: err:%v", err)
+		return nil, err
+	}
+	list := make([]*biz.AppealInfo, 0, len(ret.List))
+	for _, v := range ret.List {
+		list = append(list, toAppealInfo(v))
+	}
+	return list, nil
+}
+
+func toAppealInfo(v *v1.AppealInfo) *biz.AppealInfo {
+	return &biz.AppealInfo{
+		AppealID:    v.AppealID,
+		ReviewID:    v.ReviewID,
+		StoreID:     v.StoreID,
+		Status:      v.Status,
+		Reason:      v.Reason,
+		Content:     v.Content,
+		PicInfo:     v.PicInfo,
+		VideoInfo:   v.VideoInfo,
+		SubmitCount: v.SubmitCount,
+		OpReason:    v.OpReason,
+		OpRemarks:   v.OpRemarks,
+		Version:     v.Version,
+		DeadlineAt:  v.DeadlineAt,
+		SLAStatus:   v.SlaStatus,
+		CreateAt:    v.CreateAt,
+		UpdateAt:    v.UpdateAt,
+	}
+}
+
 func NewBusinessRepo(data *Data, logger log.Logger) biz.BusinessRepo {
 	return &businessRepo{
 		data: data,
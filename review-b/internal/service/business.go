@@ -23,9 +23,138 @@ func (s *BusinessService) ReplyReview(ctx context.Context, req *pb.ReplyReviewRe
 			Content:   req.Content,
 			PicInfo:   req.PicInfo,
 			VideoInfo: req.VideoInfo,
+			Version:   req.Version,
 		})
 	if err != nil {
 		return nil, err
 	}
 	return &pb.ReplyReviewReply{ReplyID: replyID}, nil
 }
+
+func (s *BusinessService) UpdateReply(ctx context.Context, req *pb.UpdateReplyRequest) (*pb.UpdateReplyReply, error) {
+	err := s.uc.UpdateReply(ctx, &biz.UpdateReplyParam{
+		ReplyID:   req.ReplyID,
+		StoreID:   req.StoreID,
+		Content:   req.Content,
+		PicInfo:   req.PicInfo,
+		VideoInfo: req.VideoInfo,
+		Version:   req.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UpdateReplyReply{ReplyID: req.ReplyID}, nil
+}
+
+func (s *BusinessService) DeleteReply(ctx context.Context, req *pb.DeleteReplyRequest) (*pb.DeleteReplyReply, error) {
+	err := s.uc.DeleteReply(ctx, &biz.DeleteReplyParam{
+		ReplyID: req.ReplyID,
+		StoreID: req.StoreID,
+		Version: req.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeleteReplyReply{ReplyID: req.ReplyID}, nil
+}
+
+func (s *BusinessService) GetStoreSummary(ctx context.Context, req *pb.GetStoreSummaryRequest) (*pb.GetStoreSummaryReply, error) {
+	summary, err := s.uc.GetStoreSummary(ctx, req.StoreID)
+	if err != nil {
+		return nil, err
+	}
+	distribution := make([]*pb.StarCount, 0, len(summary.StarCounts))
+	for star := int32(1); star <= 5; star++ {
+		distribution = append(distribution, &pb.StarCount{Star: star, Count: summary.StarCounts[star]})
+	}
+	return &pb.GetStoreSummaryReply{
+		StoreID:         summary.StoreID,
+		ReviewCount:     summary.ReviewCount,
+		AvgScore:        summary.AvgScore,
+		AvgServiceScore: summary.AvgServiceScore,
+		AvgExpressScore: summary.AvgExpressScore,
+		Distribution:    distribution,
+		MediaRatio:      summary.MediaRatio,
+		ReplyRatio:      summary.ReplyRatio,
+	}, nil
+}
+
+func (s *BusinessService) AppealReview(ctx context.Context, req *pb.AppealReviewRequest) (*pb.AppealReviewReply, error) {
+	appeal, err := s.uc.AppealReview(ctx, &biz.AppealParam{
+		ReviewID:  req.ReviewID,
+		StoreID:   req.StoreID,
+		Reason:    req.Reason,
+		Content:   req.Content,
+		PicInfo:   req.PicInfo,
+		VideoInfo: req.VideoInfo,
+		OpUser:    req.OpUser,
+		Version:   req.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AppealReviewReply{
+		AppealID:    appeal.AppealID,
+		Status:      appeal.Status,
+		SubmitCount: appeal.SubmitCount,
+		Version:     appeal.Version,
+	}, nil
+}
+
+func (s *BusinessService) WithdrawAppeal(ctx context.Context, req *pb.WithdrawAppealRequest) (*pb.WithdrawAppealReply, error) {
+	err := s.uc.WithdrawAppeal(ctx, &biz.WithdrawAppealParam{
+		AppealID: req.AppealID,
+		StoreID:  req.StoreID,
+		Version:  req.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.WithdrawAppealReply{AppealID: req.AppealID}, nil
+}
+
+func (s *BusinessService) GetAppeal(ctx context.Context, req *pb.GetAppealRequest) (*pb.GetAppealReply, error) {
+	appeal, err := s.uc.GetAppeal(ctx, req.AppealID, req.StoreID)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetAppealReply{Data: toAppealInfo(appeal)}, nil
+}
+
+func (s *BusinessService) ListMyAppeals(ctx context.Context, req *pb.ListMyAppealsRequest) (*pb.ListMyAppealsReply, error) {
+	ret, err := s.uc.ListMyAppeals(ctx, &biz.ListAppealParam{
+		StoreID: req.StoreID,
+		Status:  req.Status,
+		Page:    req.Page,
+		Size:    req.Size,
+	})
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*pb.AppealInfo, 0, len(ret))
+	for _, v := range ret {
+		list = append(list, toAppealInfo(v))
+	}
+	return &pb.ListMyAppealsReply{List: list}, nil
+}
+
+func toAppealInfo(v *biz.AppealInfo) *pb.AppealInfo {
+	return &pb.AppealInfo{
+		AppealID:    v.AppealID,
+		ReviewID:    v.ReviewID,
+		StoreID:     v.StoreID,
+		Status:      v.Status,
+		Reason:      v.Reason,
+		Content:     v.Content,
+		PicInfo:     v.PicInfo,
+		VideoInfo:   v.VideoInfo,
+		SubmitCount: v.SubmitCount,
+		OpReason:    v.OpReason,
+		OpRemarks:   v.OpRemarks,
+		Version:     v.Version,
+		DeadlineAt:  v.DeadlineAt,
+		SlaStatus:   v.SLAStatus,
+		CreateAt:    v.CreateAt,
+		UpdateAt:    v.UpdateAt,
+	}
+}
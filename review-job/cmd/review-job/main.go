@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
+	"os/signal"
 	"review-job/internal/job"
+	"syscall"
 
 	"review-job/internal/conf"
 
@@ -26,15 +29,18 @@ var (
 	Version string
 	// flagconf is the config flag.
 	flagconf string
+	// replayDLQ 重放死信队列中的消息，处理完后退出
+	replayDLQ bool
 
 	id, _ = os.Hostname()
 )
 
 func init() {
 	flag.StringVar(&flagconf, "conf", "../../configs", "config path, eg: -conf config.yaml")
+	flag.BoolVar(&replayDLQ, "replay-dlq", false, "replay messages in the dead letter queue and exit")
 }
 
-func newApp(logger log.Logger, gs *grpc.Server, js *job.JobWorker, hs *http.Server) *kratos.App {
+func newApp(logger log.Logger, gs *grpc.Server, js *job.JobWorker, ds *job.DefaultReviewWorker, as *job.AppealSLAWorker, hs *http.Server) *kratos.App {
 	return kratos.New(
 		kratos.ID(id),
 		kratos.Name(Name),
@@ -45,6 +51,8 @@ func newApp(logger log.Logger, gs *grpc.Server, js *job.JobWorker, hs *http.Serv
 			gs,
 			hs,
 			js,
+			ds,
+			as,
 		),
 	)
 }
@@ -76,7 +84,12 @@ func main() {
 		panic(err)
 	}
 
-	app, cleanup, err := wireApp(bc.Server, bc.Kafka, bc.Elasticsearch, bc.Data, logger)
+	if replayDLQ {
+		replayDeadLetters(&bc, logger)
+		return
+	}
+
+	app, cleanup, err := wireApp(bc.Server, bc.Kafka, bc.Elasticsearch, bc.Data, bc.DefaultReview, bc.AppealSla, bc.Alert, logger)
 	if err != nil {
 		panic(err)
 	}
@@ -87,3 +100,22 @@ func main() {
 		panic(err)
 	}
 }
+
+// replayDeadLetters 把死信队列中的消息按正常消费的流程重新处理一遍，处理完后退出
+func replayDeadLetters(bc *conf.Bootstrap, logger log.Logger) {
+	helper := log.NewHelper(logger)
+	replayer, cleanup, err := wireDLQReplayer(bc.Kafka, bc.Elasticsearch, bc.Alert, logger)
+	if err != nil {
+		panic(err)
+	}
+	defer cleanup()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	n, err := replayer.Run(ctx)
+	if err != nil {
+		helper.Errorf("replay dlq failed after %d messages: %v", n, err)
+		return
+	}
+	helper.Infof("replay dlq done, replayed:%d", n)
+}
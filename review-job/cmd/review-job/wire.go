@@ -19,6 +19,11 @@ import (
 )
 
 // wireApp init kratos application.
-func wireApp(*conf.Server, *conf.Kafka, *conf.Elasticsearch, *conf.Data, log.Logger) (*kratos.App, func(), error) {
+func wireApp(*conf.Server, *conf.Kafka, *conf.Elasticsearch, *conf.Data, *conf.DefaultReview, *conf.AppealSLA, *conf.Alert, log.Logger) (*kratos.App, func(), error) {
 	panic(wire.Build(server.ProviderSet, job.ProviderSet, data.ProviderSet, biz.ProviderSet, service.ProviderSet, newApp))
 }
+
+// wireDLQReplayer init dead letter queue replayer.
+func wireDLQReplayer(*conf.Kafka, *conf.Elasticsearch, *conf.Alert, log.Logger) (*job.DLQReplayer, func(), error) {
+	panic(wire.Build(job.NewESClient, job.NewDLQWriter, job.NewAlerter, job.NewDLQReplayer))
+}
@@ -24,7 +24,7 @@ import (
 // Injectors from wire.go:
 
 // wireApp init kratos application.
-func wireApp(confServer *conf.Server, kafka *conf.Kafka, elasticsearch *conf.Elasticsearch, confData *conf.Data, logger log.Logger) (*kratos.App, func(), error) {
+func wireApp(confServer *conf.Server, kafka *conf.Kafka, elasticsearch *conf.Elasticsearch, confData *conf.Data, defaultReview *conf.DefaultReview, appealSLA *conf.AppealSLA, alert *conf.Alert, logger log.Logger) (*kratos.App, func(), error) {
 	dataData, cleanup, err := data.NewData(confData, logger)
 	if err != nil {
 		return nil, nil, err
@@ -39,10 +39,53 @@ func wireApp(confServer *conf.Server, kafka *conf.Kafka, elasticsearch *conf.Ela
 		cleanup()
 		return nil, nil, err
 	}
-	jobWorker := job.NewJobWorker(reader, esClient, logger)
+	writer, cleanup2 := job.NewDLQWriter(kafka)
+	alerter := job.NewAlerter(alert, logger)
+	jobWorker := job.NewJobWorker(reader, esClient, writer, alerter, kafka, logger)
+	orderSource, err := job.NewOrderSource(defaultReview)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	defaultReviewWorker, cleanup3, err := job.NewDefaultReviewWorker(defaultReview, orderSource, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	appealSLAWorker, cleanup4, err := job.NewAppealSLAWorker(appealSLA, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
 	httpServer := server.NewHTTPServer(confServer, greeterService, logger)
-	app := newApp(logger, grpcServer, jobWorker, httpServer)
+	app := newApp(logger, grpcServer, jobWorker, defaultReviewWorker, appealSLAWorker, httpServer)
 	return app, func() {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+// wireDLQReplayer init dead letter queue replayer.
+func wireDLQReplayer(kafka *conf.Kafka, elasticsearch *conf.Elasticsearch, alert *conf.Alert, logger log.Logger) (*job.DLQReplayer, func(), error) {
+	esClient, err := job.NewESClient(elasticsearch)
+	if err != nil {
+		return nil, nil, err
+	}
+	writer, cleanup := job.NewDLQWriter(kafka)
+	alerter := job.NewAlerter(alert, logger)
+	dlqReplayer, cleanup2, err := job.NewDLQReplayer(kafka, esClient, writer, alerter, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return dlqReplayer, func() {
+		cleanup2()
 		cleanup()
 	}, nil
 }
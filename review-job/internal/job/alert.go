@@ -0,0 +1,63 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"review-job/internal/conf"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Alerter 需要人工处理的事件（比如表结构变更）记录错误日志，配置了webhook时同时发给告警机器人
+type Alerter struct {
+	webhook string
+	client  *http.Client
+	log     *log.Helper
+}
+
+func NewAlerter(cfg *conf.Alert, logger log.Logger) *Alerter {
+	return &Alerter{
+		webhook: cfg.GetWebhook(),
+		client:  &http.Client{Timeout: 3 * time.Second},
+		log:     log.NewHelper(logger),
+	}
+}
+
+// webhookMessage 钉钉、企业微信机器人通用的文本消息格式
+type webhookMessage struct {
+	MsgType string `json:"msgtype"`
+	Text    struct {
+		Content string `json:"content"`
+	} `json:"text"`
+}
+
+// Alert 发送告警，发送失败只记录日志，不影响调用方
+func (a *Alerter) Alert(ctx context.Context, format string, args ...interface{}) {
+	content := fmt.Sprintf(format, args...)
+	a.log.WithContext(ctx).Errorf("[alert] %s", content)
+	if len(a.webhook) == 0 {
+		return
+	}
+	msg := webhookMessage{MsgType: "text"}
+	msg.Text.Content = "[review-job] " + content
+	b, _ := json.Marshal(msg)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.webhook, bytes.NewReader(b))
+	if err != nil {
+		a.log.WithContext(ctx).Errorf("failed to create alert request: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.client.Do(req)
+	if err != nil {
+		a.log.WithContext(ctx).Errorf("failed to send alert: %v", err)
+		return
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		a.log.WithContext(ctx).Errorf("failed to send alert, status:%d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,112 @@
+package job
+
+import (
+	"context"
+	"review-job/internal/conf"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// 申诉时效检查定时任务
+// 定时调用review-service检查待审核的申诉：超过审核截止时间的升级到高级审核队列，配置了自动关闭的超时后关闭
+// 截止时间的计算策略和自动关闭的时长都在review-service中配置，这里只负责定时触发
+
+// maxAppealSLABatchSize review-service CheckAppealSLA每次最多处理的申诉数，配置的batch_size超过时按这个值请求
+// 否则返回的数量永远达不到batch_size，一批处理完就会停止
+const maxAppealSLABatchSize = 500
+
+// AppealSLAWorker 申诉时效检查任务，实现transport.Server
+type AppealSLAWorker struct {
+	cfg       *conf.AppealSLA
+	batchSize int32
+	client    *http.Client
+	stop      chan struct{}
+	log       *log.Helper
+}
+
+func NewAppealSLAWorker(cfg *conf.AppealSLA, logger log.Logger) (*AppealSLAWorker, func(), error) {
+	client, err := http.NewClient(
+		context.Background(),
+		http.WithEndpoint(cfg.ReviewService),
+		http.WithTimeout(10*time.Second),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	w := &AppealSLAWorker{
+		cfg:       cfg,
+		batchSize: cfg.BatchSize,
+		client:    client,
+		stop:      make(chan struct{}),
+		log:       log.NewHelper(logger),
+	}
+	// 和review-service的处理一致：未配置时每批100条，超过上限时按上限处理
+	switch {
+	case w.batchSize <= 0:
+		w.batchSize = 100
+	case w.batchSize > maxAppealSLABatchSize:
+		w.log.Warnf("appeal sla batch_size %d exceeds %d, use %d", w.batchSize, maxAppealSLABatchSize, maxAppealSLABatchSize)
+		w.batchSize = maxAppealSLABatchSize
+	}
+	cleanup := func() {
+		_ = client.Close()
+	}
+	return w, cleanup, nil
+}
+
+// checkAppealSLARequest 对应review-service CheckAppealSLARequest
+type checkAppealSLARequest struct {
+	Limit int32 `json:"limit"`
+}
+
+type checkAppealSLAReply struct {
+	Escalated int32 `json:"escalated"`
+	Closed    int32 `json:"closed"`
+}
+
+// Start 按配置的间隔执行任务，启动时先执行一次
+func (w *AppealSLAWorker) Start(ctx context.Context) error {
+	w.log.Debugf("start appeal sla worker.....")
+	ticker := time.NewTicker(w.cfg.Interval.AsDuration())
+	defer ticker.Stop()
+	for {
+		if err := w.run(ctx); err != nil {
+			w.log.Errorf("appeal sla run failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// run 执行一次检查，每批处理满了说明可能还有超时的申诉，继续处理
+func (w *AppealSLAWorker) run(ctx context.Context) error {
+	var escalated, closed int32
+	for {
+		reply := &checkAppealSLAReply{}
+		req := &checkAppealSLARequest{Limit: w.batchSize}
+		if err := w.client.Invoke(ctx, "POST", "/v1/appeal/sla/check", req, reply); err != nil {
+			return err
+		}
+		escalated += reply.Escalated
+		closed += reply.Closed
+		if reply.Escalated < w.batchSize && reply.Closed < w.batchSize {
+			break
+		}
+	}
+	w.log.Infof("appeal sla run done, escalated:%d closed:%d", escalated, closed)
+	return nil
+}
+
+// Stop kratos结束后调用的
+func (w *AppealSLAWorker) Stop(ctx context.Context) error {
+	w.log.Debugf("stopping appeal sla worker")
+	close(w.stop)
+	return nil
+}
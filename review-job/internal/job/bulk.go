@@ -0,0 +1,229 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/segmentio/kafka-go"
+)
+
+// 批量写入ES
+// 消息先转换成bulk操作攒成一批，操作数、请求体大小或者等待时间任意一个达到配置时执行一次_bulk请求
+// bulk响应中逐条检查每个操作的结果，临时失败的操作按退避时间重试，一批消息全部写入ES或者写入死信队列后才提交offset
+
+const (
+	defaultBulkMaxActions    = 500
+	defaultBulkMaxBytes      = 5 << 20
+	defaultBulkFlushInterval = time.Second
+)
+
+// bulk操作类型，评价文档都是按版本号局部更新，删除评价也只是写入delete_at，不用index覆盖或者delete
+const (
+	bulkUpdate = "update"
+)
+
+// bulkOp 一个bulk操作，对应_bulk请求体中的action行和文档行
+type bulkOp struct {
+	kind   string
+	id     string // 评价文档ID
+	msg    int    // 所属消息在批次中的下标
+	action []byte
+	body   []byte
+}
+
+func newBulkOp(kind, id string, body interface{}) (*bulkOp, error) {
+	action, err := json.Marshal(map[string]map[string]string{kind: {"_id": id}})
+	if err != nil {
+		return nil, err
+	}
+	op := &bulkOp{kind: kind, id: id, action: action}
+	if body != nil {
+		if op.body, err = json.Marshal(body); err != nil {
+			return nil, fmt.Errorf("%w: %v", errMalformedMessage, err)
+		}
+	}
+	return op, nil
+}
+
+// size 操作在请求体中占的字节数
+func (op *bulkOp) size() int {
+	n := len(op.action) + 1
+	if op.body != nil {
+		n += len(op.body) + 1
+	}
+	return n
+}
+
+// bulkBatch 还没有提交offset的一批消息和它们的bulk操作
+type bulkBatch struct {
+	msgs     []kafka.Message
+	ops      []*bulkOp
+	bytes    int
+	deadline time.Time // 批次中第一条消息最晚的写入时间
+}
+
+func (b *bulkBatch) empty() bool {
+	return len(b.msgs) == 0
+}
+
+// add 把消息转换成bulk操作加入当前批次
+// 无法解析的消息直接写入死信队列，offset随批次一起提交；只在ctx取消时返回错误
+func (job *JobWorker) add(ctx context.Context, m kafka.Message) error {
+	ops, err := job.buildOps(ctx, m)
+	if err != nil {
+		job.log.Errorf("failed to parse message at partition/offset %v/%v, send to dlq: %v", m.Partition, m.Offset, err)
+		if err := job.deadLetter(ctx, m, err, 1); err != nil {
+			return err
+		}
+		ops = nil
+	}
+	b := job.batch
+	if b.empty() {
+		b.deadline = time.Now().Add(job.esClient.flushInterval)
+	}
+	b.msgs = append(b.msgs, m)
+	for _, op := range ops {
+		op.msg = len(b.msgs) - 1
+		b.ops = append(b.ops, op)
+		b.bytes += op.size()
+	}
+	return nil
+}
+
+// full 批次的操作数或者请求体大小达到配置，需要立即写入
+// 消息都被跳过时操作数为0，按消息数计算，避免攒太多未提交的消息
+func (job *JobWorker) full() bool {
+	b := job.batch
+	return len(b.ops) >= job.esClient.maxActions ||
+		len(b.msgs) >= job.esClient.maxActions ||
+		b.bytes >= job.esClient.maxBytes
+}
+
+// flush 把当前批次写入ES后提交offset
+// 临时失败的操作重试maxAttempts次，仍然失败或者ES拒绝写入的，所属消息写入死信队列
+// 只在ctx取消时返回错误，此时不提交offset，重启后整批重新消费
+func (job *JobWorker) flush(ctx context.Context) error {
+	b := job.batch
+	if b.empty() {
+		return nil
+	}
+	failed := make(map[int]error) // 写入失败的消息下标和原因
+	pending := b.ops
+	backoff := job.retryBackoff
+	attempt := 1
+	for len(pending) > 0 {
+		var retry []*bulkOp
+		var lastErr error
+		errs, err := job.doBulk(ctx, pending)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		switch {
+		case err != nil && isRetryable(err):
+			retry, lastErr = pending, err
+		case err != nil:
+			for _, op := range pending {
+				failed[op.msg] = err
+			}
+		default:
+			// 同一个文档前面的操作要重试时，后面的操作也一起重试，保证按消息顺序写入
+			retrying := make(map[string]bool)
+			for i, op := range pending {
+				switch {
+				case retrying[op.id]:
+					retry = append(retry, op)
+				case errs[i] == nil:
+				case isRetryable(errs[i]):
+					retrying[op.id] = true
+					retry = append(retry, op)
+					lastErr = errs[i]
+				default:
+					failed[op.msg] = errs[i]
+				}
+			}
+		}
+		if len(retry) == 0 {
+			break
+		}
+		if attempt >= job.maxAttempts {
+			for _, op := range retry {
+				if _, ok := failed[op.msg]; !ok {
+					failed[op.msg] = lastErr
+				}
+			}
+			break
+		}
+		job.log.Warnf("failed to write %d of %d bulk actions, attempt:%d, retry after %v: %v",
+			len(retry), len(b.ops), attempt, backoff, lastErr)
+		if !sleepCtx(ctx, backoff) {
+			return ctx.Err()
+		}
+		backoff = job.nextBackoff(backoff)
+		attempt++
+		pending = retry
+	}
+
+	for i, m := range b.msgs {
+		err, ok := failed[i]
+		if !ok {
+			continue
+		}
+		job.log.Errorf("failed to write message at partition/offset %v/%v after %d attempts, send to dlq: %v",
+			m.Partition, m.Offset, attempt, err)
+		if err := job.deadLetter(ctx, m, err, attempt); err != nil {
+			return err
+		}
+	}
+	if err := job.kafkaReader.CommitMessages(ctx, b.msgs...); err != nil {
+		// 提交失败时后面的批次提交成功也会覆盖这批消息的offset
+		job.log.Errorf("failed to commit %d messages: %v", len(b.msgs), err)
+	}
+	job.log.Debugf("bulk flushed, messages:%d, actions:%d, failed:%d", len(b.msgs), len(b.ops), len(failed))
+	job.batch = new(bulkBatch)
+	return nil
+}
+
+// doBulk 执行一次_bulk请求，返回每个操作的错误，写入成功的为nil
+func (job *JobWorker) doBulk(ctx context.Context, ops []*bulkOp) ([]error, error) {
+	var buf bytes.Buffer
+	for _, op := range ops {
+		buf.Write(op.action)
+		buf.WriteByte('\n')
+		if op.body != nil {
+			buf.Write(op.body)
+			buf.WriteByte('\n')
+		}
+	}
+	resp, err := job.esClient.Bulk().Index(job.esClient.index).Raw(&buf).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to do bulk request: %w", err)
+	}
+	if len(resp.Items) != len(ops) {
+		return nil, fmt.Errorf("bulk response has %d items, want %d", len(resp.Items), len(ops))
+	}
+	errs := make([]error, len(ops))
+	for i, item := range resp.Items {
+		// 每个item只有一个key，就是操作类型
+		for _, ret := range item {
+			errs[i] = bulkItemError(ops[i], ret)
+		}
+	}
+	return errs, nil
+}
+
+// bulkItemError 把bulk响应中单个操作的失败转换成ElasticsearchError，按状态码判断是否重试
+func bulkItemError(op *bulkOp, ret types.ResponseItem) error {
+	if ret.Status >= http.StatusOK && ret.Status < http.StatusMultipleChoices {
+		return nil
+	}
+	esErr := &types.ElasticsearchError{Status: ret.Status}
+	if ret.Error != nil {
+		esErr.ErrorCause = *ret.Error
+	}
+	return fmt.Errorf("failed to %s document:%s, err:%w", op.kind, op.id, esErr)
+}
@@ -0,0 +1,146 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"review-job/internal/conf"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// 默认评价定时任务
+// 1.从订单来源分批查询完成超过N天的订单
+// 2.调用review-service为没有评价的订单商品创建默认好评，已评价的由review-service跳过，所以可以重复执行
+
+// DefaultReviewWorker 默认评价任务，实现transport.Server
+type DefaultReviewWorker struct {
+	cfg    *conf.DefaultReview
+	source OrderSource
+	client *http.Client
+	stop   chan struct{}
+	log    *log.Helper
+}
+
+func NewDefaultReviewWorker(cfg *conf.DefaultReview, source OrderSource, logger log.Logger) (*DefaultReviewWorker, func(), error) {
+	client, err := http.NewClient(
+		context.Background(),
+		http.WithEndpoint(cfg.ReviewService),
+		http.WithTimeout(10*time.Second),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	w := &DefaultReviewWorker{
+		cfg:    cfg,
+		source: source,
+		client: client,
+		stop:   make(chan struct{}),
+		log:    log.NewHelper(logger),
+	}
+	cleanup := func() {
+		_ = client.Close()
+	}
+	return w, cleanup, nil
+}
+
+// defaultReviewItem 对应review-service CreateDefaultReviewsRequest中的item
+type defaultReviewItem struct {
+	OrderID int64 `json:"orderID"`
+	UserID  int64 `json:"userID"`
+	StoreID int64 `json:"storeID"`
+	SkuID   int64 `json:"skuID"`
+	SpuID   int64 `json:"spuID"`
+}
+
+type createDefaultReviewsRequest struct {
+	Items []*defaultReviewItem `json:"items"`
+}
+
+type createDefaultReviewsReply struct {
+	ReviewIDs []string `json:"reviewIDs"` // int64在protojson中序列化为字符串
+}
+
+// Start 按配置的间隔执行任务，启动时先执行一次
+func (w *DefaultReviewWorker) Start(ctx context.Context) error {
+	if w.source == nil {
+		w.log.Warnf("default review worker disabled, order source is not configured")
+		return nil
+	}
+	w.log.Debugf("start default review worker.....")
+	ticker := time.NewTicker(w.cfg.Interval.AsDuration())
+	defer ticker.Stop()
+	for {
+		if _, err := w.run(ctx); err != nil {
+			w.log.Errorf("default review run failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// run 执行一次扫描，返回新创建的评价数
+func (w *DefaultReviewWorker) run(ctx context.Context) (int, error) {
+	end := time.Now().AddDate(0, 0, -int(w.cfg.Days))
+	start := end.Add(-w.cfg.Lookback.AsDuration())
+	limit := int(w.cfg.BatchSize)
+	var afterOrderID int64
+	total := 0
+	for {
+		orders, err := w.source.ListCompletedOrders(ctx, start, end, afterOrderID, limit)
+		if err != nil {
+			return total, fmt.Errorf("list completed orders: %w", err)
+		}
+		if len(orders) == 0 {
+			break
+		}
+		n, err := w.createDefaultReviews(ctx, orders)
+		if err != nil {
+			return total, fmt.Errorf("create default reviews after order %d: %w", afterOrderID, err)
+		}
+		total += n
+		afterOrderID = orders[len(orders)-1].OrderID
+		if len(orders) < limit {
+			break
+		}
+	}
+	w.log.Infof("default review run done, start:%v end:%v created:%d", start, end, total)
+	return total, nil
+}
+
+// createDefaultReviews 一批订单的所有商品一次性提交，返回新创建的评价数
+func (w *DefaultReviewWorker) createDefaultReviews(ctx context.Context, orders []*Order) (int, error) {
+	req := &createDefaultReviewsRequest{}
+	for _, o := range orders {
+		for _, item := range o.Items {
+			req.Items = append(req.Items, &defaultReviewItem{
+				OrderID: o.OrderID,
+				UserID:  o.UserID,
+				StoreID: o.StoreID,
+				SkuID:   item.SkuID,
+				SpuID:   item.SpuID,
+			})
+		}
+	}
+	if len(req.Items) == 0 {
+		return 0, nil
+	}
+	reply := &createDefaultReviewsReply{}
+	if err := w.client.Invoke(ctx, "POST", "/v1/review/default", req, reply); err != nil {
+		return 0, err
+	}
+	return len(reply.ReviewIDs), nil
+}
+
+// Stop kratos结束后调用的
+func (w *DefaultReviewWorker) Stop(ctx context.Context) error {
+	w.log.Debugf("stopping default review worker")
+	close(w.stop)
+	return nil
+}
@@ -0,0 +1,170 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	nethttp "net/http"
+	"net/http/httptest"
+	"review-job/internal/conf"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// fakeReviewService 模拟review-service的CreateDefaultReviews，已经评价过的订单商品跳过
+type fakeReviewService struct {
+	mu       sync.Mutex
+	reviewed map[string]bool
+	orders   map[int64]bool // 收到过的订单
+}
+
+func newFakeReviewService(t *testing.T) (*fakeReviewService, string) {
+	t.Helper()
+	f := &fakeReviewService{reviewed: make(map[string]bool), orders: make(map[int64]bool)}
+	srv := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if r.Method != nethttp.MethodPost || r.URL.Path != "/v1/review/default" {
+			nethttp.NotFound(w, r)
+			return
+		}
+		req := &createDefaultReviewsRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			nethttp.Error(w, err.Error(), nethttp.StatusBadRequest)
+			return
+		}
+		reply := &createDefaultReviewsReply{ReviewIDs: []string{}}
+		f.mu.Lock()
+		for _, item := range req.Items {
+			f.orders[item.OrderID] = true
+			key := fmt.Sprintf("%d-%d", item.OrderID, item.SkuID)
+			if f.reviewed[key] {
+				continue
+			}
+			f.reviewed[key] = true
+			reply.ReviewIDs = append(reply.ReviewIDs, fmt.Sprint(len(f.reviewed)))
+		}
+		f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(reply)
+	}))
+	t.Cleanup(srv.Close)
+	return f, strings.TrimPrefix(srv.URL, "http://")
+}
+
+func (f *fakeReviewService) orderIDs() []int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ids := make([]int64, 0, len(f.orders))
+	for id := range f.orders {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func newTestDefaultReviewWorker(t *testing.T, endpoint string, source OrderSource) *DefaultReviewWorker {
+	t.Helper()
+	cfg := &conf.DefaultReview{
+		Days:          15,
+		Interval:      durationpb.New(time.Hour),
+		Lookback:      durationpb.New(24 * time.Hour),
+		BatchSize:     2,
+		ReviewService: endpoint,
+	}
+	w, cleanup, err := NewDefaultReviewWorker(cfg, source, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(cleanup)
+	return w
+}
+
+// 只处理完成时间在[now-days-lookback, now-days)之间的订单，按batch_size分批
+func TestDefaultReviewRunWindow(t *testing.T) {
+	svc, endpoint := newFakeReviewService(t)
+	end := time.Now().AddDate(0, 0, -15)
+	start := end.Add(-24 * time.Hour)
+	source := NewLocalOrderSource(
+		&Order{OrderID: 1, UserID: 11, StoreID: 21, CompleteAt: end.Add(-time.Hour),
+			Items: []*OrderItem{{SkuID: 101, SpuID: 201}, {SkuID: 102, SpuID: 202}}},
+		&Order{OrderID: 2, UserID: 12, StoreID: 21, CompleteAt: start.Add(time.Hour),
+			Items: []*OrderItem{{SkuID: 103, SpuID: 203}}},
+		&Order{OrderID: 3, UserID: 13, StoreID: 22, CompleteAt: end.Add(time.Hour), // 还没到默认好评时间
+			Items: []*OrderItem{{SkuID: 104, SpuID: 204}}},
+		&Order{OrderID: 4, UserID: 14, StoreID: 22, CompleteAt: start.Add(-time.Hour), // 上一次扫描已经处理过
+			Items: []*OrderItem{{SkuID: 105, SpuID: 205}}},
+		&Order{OrderID: 5, UserID: 15, StoreID: 23, CompleteAt: end.Add(-2 * time.Hour),
+			Items: []*OrderItem{{SkuID: 106, SpuID: 206}}},
+	)
+	w := newTestDefaultReviewWorker(t, endpoint, source)
+
+	created, err := w.run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created != 4 {
+		t.Fatalf("run created %d reviews, want 4", created)
+	}
+	if got := fmt.Sprint(svc.orderIDs()); got != "[1 2 5]" {
+		t.Fatalf("orders sent to review-service = %s, want [1 2 5]", got)
+	}
+}
+
+// 重复执行时已经评价过的订单商品由review-service跳过，不会重复创建
+func TestDefaultReviewRunIdempotent(t *testing.T) {
+	svc, endpoint := newFakeReviewService(t)
+	completeAt := time.Now().AddDate(0, 0, -15).Add(-time.Hour)
+	source := NewLocalOrderSource(
+		&Order{OrderID: 1, UserID: 11, StoreID: 21, CompleteAt: completeAt,
+			Items: []*OrderItem{{SkuID: 101, SpuID: 201}}},
+		&Order{OrderID: 2, UserID: 12, StoreID: 21, CompleteAt: completeAt,
+			Items: []*OrderItem{{SkuID: 102, SpuID: 202}}},
+	)
+	w := newTestDefaultReviewWorker(t, endpoint, source)
+
+	first, err := w.run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := w.run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != 2 || second != 0 {
+		t.Fatalf("created first:%d second:%d, want 2 and 0", first, second)
+	}
+	if len(svc.reviewed) != 2 {
+		t.Fatalf("review-service has %d reviews, want 2", len(svc.reviewed))
+	}
+}
+
+func TestNewOrderSource(t *testing.T) {
+	source, err := NewOrderSource(&conf.DefaultReview{})
+	if err != nil || source != nil {
+		t.Fatalf("NewOrderSource() without config = %v, %v, want nil, nil", source, err)
+	}
+	source, err = NewOrderSource(&conf.DefaultReview{OrderSource: orderSourceLocal})
+	if _, ok := source.(*LocalOrderSource); err != nil || !ok {
+		t.Fatalf("NewOrderSource(local) = %T, %v, want *LocalOrderSource", source, err)
+	}
+	if _, err := NewOrderSource(&conf.DefaultReview{OrderSource: "mysql"}); err == nil {
+		t.Fatal("NewOrderSource(mysql) should fail")
+	}
+}
+
+// 没有配置订单来源时任务直接返回，不调用review-service
+func TestDefaultReviewDisabled(t *testing.T) {
+	svc, endpoint := newFakeReviewService(t)
+	w := newTestDefaultReviewWorker(t, endpoint, nil)
+	if err := w.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(svc.orderIDs()) != 0 {
+		t.Fatal("disabled worker should not call review-service")
+	}
+}
@@ -0,0 +1,195 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"review-job/internal/conf"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/segmentio/kafka-go"
+)
+
+// 死信队列
+// 多次重试仍然写不进ES、或者无法解析的消息原样写入死信队列，header中记录失败原因、处理次数和来源位置
+// 问题修复后执行 review-job -replay-dlq 把死信队列中的消息按原来的流程（攒批bulk写入）重新处理一遍
+
+// 死信消息的header
+const (
+	dlqHeaderError           = "x-dlq-error"
+	dlqHeaderAttempts        = "x-dlq-attempts"
+	dlqHeaderSourceTopic     = "x-dlq-source-topic"
+	dlqHeaderSourcePartition = "x-dlq-source-partition"
+	dlqHeaderSourceOffset    = "x-dlq-source-offset"
+)
+
+// dlqReplayIdleTimeout 重放时超过这个时间没有读到消息，认为死信队列已经处理完了
+const dlqReplayIdleTimeout = 10 * time.Second
+
+func dlqTopic(cfg *conf.Kafka) string {
+	if len(cfg.DlqTopic) > 0 {
+		return cfg.DlqTopic
+	}
+	return cfg.Topic + ".dlq"
+}
+
+// NewDLQWriter 死信队列的producer，按原消息的key分区
+func NewDLQWriter(cfg *conf.Kafka) (*kafka.Writer, func()) {
+	w := &kafka.Writer{
+		Addr:                   kafka.TCP(cfg.Brokers...),
+		Topic:                  dlqTopic(cfg),
+		Balancer:               &kafka.Hash{},
+		RequiredAcks:           kafka.RequireAll,
+		AllowAutoTopicCreation: true,
+	}
+	cleanup := func() {
+		_ = w.Close()
+	}
+	return w, cleanup
+}
+
+// deadLetter 把消息原样写入死信队列，写入失败时按退避时间一直重试，只在ctx取消时返回错误
+// 写入成功之前不能提交原消息的offset，否则消息就丢了
+func (job *JobWorker) deadLetter(ctx context.Context, m kafka.Message, cause error, attempts int) error {
+	headers := make([]kafka.Header, 0, len(m.Headers)+5)
+	// 从死信队列重放时再次失败，去掉上一次的死信header
+	for _, h := range m.Headers {
+		if !strings.HasPrefix(h.Key, "x-dlq-") {
+			headers = append(headers, h)
+		}
+	}
+	headers = append(headers,
+		kafka.Header{Key: dlqHeaderError, Value: []byte(cause.Error())},
+		kafka.Header{Key: dlqHeaderAttempts, Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: dlqHeaderSourceTopic, Value: []byte(m.Topic)},
+		kafka.Header{Key: dlqHeaderSourcePartition, Value: []byte(strconv.Itoa(m.Partition))},
+		kafka.Header{Key: dlqHeaderSourceOffset, Value: []byte(strconv.FormatInt(m.Offset, 10))},
+	)
+	dlqMsg := kafka.Message{Key: m.Key, Value: m.Value, Headers: headers}
+
+	backoff := job.retryBackoff
+	for {
+		err := job.dlqWriter.WriteMessages(ctx, dlqMsg)
+		if err == nil {
+			return nil
+		}
+		job.log.Errorf("failed to write message at partition/offset %v/%v to dlq, retry after %v: %v",
+			m.Partition, m.Offset, backoff, err)
+		if !sleepCtx(ctx, backoff) {
+			return ctx.Err()
+		}
+		backoff = job.nextBackoff(backoff)
+	}
+}
+
+// DLQReplayer 重放死信队列，使用单独的消费者组，重放进度和正常消费互不影响
+type DLQReplayer struct {
+	worker *JobWorker
+	client *kafka.Client
+	topic  string
+	log    *log.Helper
+}
+
+func NewDLQReplayer(cfg *conf.Kafka, esClient *ESClient, dlqWriter *kafka.Writer, alerter *Alerter, logger log.Logger) (*DLQReplayer, func(), error) {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: cfg.Brokers,
+		GroupID: cfg.GroupId + "-dlq-replay",
+		Topic:   dlqTopic(cfg),
+	})
+	r := &DLQReplayer{
+		worker: NewJobWorker(reader, esClient, dlqWriter, alerter, cfg, logger),
+		client: &kafka.Client{Addr: kafka.TCP(cfg.Brokers...)},
+		topic:  dlqTopic(cfg),
+		log:    log.NewHelper(logger),
+	}
+	cleanup := func() {
+		_ = reader.Close()
+	}
+	return r, cleanup, nil
+}
+
+// Run 重放开始前已经在死信队列中的消息，处理完后返回重放的消息数
+// 重放时再次失败的消息会重新写入死信队列的末尾，本次不再处理，避免一直循环
+func (r *DLQReplayer) Run(ctx context.Context) (int, error) {
+	ends, err := r.lastOffsets(ctx)
+	if err != nil {
+		return 0, err
+	}
+	r.log.Infof("replay dlq topic:%s, last offsets:%v", r.topic, ends)
+	done := make(map[int]bool, len(ends))
+	for p, end := range ends {
+		if end <= 0 {
+			done[p] = true
+		}
+	}
+	replayed := 0
+	for len(done) < len(ends) {
+		fetchCtx, cancel := context.WithTimeout(ctx, dlqReplayIdleTimeout)
+		m, err := r.worker.kafkaReader.FetchMessage(fetchCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return replayed, ctx.Err()
+		}
+		// 剩下的分区在之前的重放中已经处理完了
+		if errors.Is(err, context.DeadlineExceeded) {
+			break
+		}
+		if err != nil {
+			return replayed, err
+		}
+		if m.Offset >= ends[m.Partition] {
+			done[m.Partition] = true
+			continue
+		}
+		if err := r.worker.add(ctx, m); err != nil {
+			return replayed, err
+		}
+		replayed++
+		if m.Offset+1 >= ends[m.Partition] {
+			done[m.Partition] = true
+		}
+		if r.worker.full() {
+			if err := r.worker.flush(ctx); err != nil {
+				return replayed, err
+			}
+		}
+	}
+	// 写入最后一批没攒满的消息
+	if err := r.worker.flush(ctx); err != nil {
+		return replayed, err
+	}
+	return replayed, nil
+}
+
+// lastOffsets 查询死信队列每个分区当前的最新offset
+func (r *DLQReplayer) lastOffsets(ctx context.Context) (map[int]int64, error) {
+	meta, err := r.client.Metadata(ctx, &kafka.MetadataRequest{Topics: []string{r.topic}})
+	if err != nil {
+		return nil, err
+	}
+	reqs := make([]kafka.OffsetRequest, 0)
+	for _, t := range meta.Topics {
+		if t.Error != nil {
+			return nil, t.Error
+		}
+		for _, p := range t.Partitions {
+			reqs = append(reqs, kafka.LastOffsetOf(p.ID))
+		}
+	}
+	resp, err := r.client.ListOffsets(ctx, &kafka.ListOffsetsRequest{
+		Topics: map[string][]kafka.OffsetRequest{r.topic: reqs},
+	})
+	if err != nil {
+		return nil, err
+	}
+	ends := make(map[int]int64, len(reqs))
+	for _, p := range resp.Topics[r.topic] {
+		if p.Error != nil {
+			return nil, p.Error
+		}
+		ends[p.Partition] = p.LastOffset
+	}
+	return ends, nil
+}
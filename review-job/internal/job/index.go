@@ -0,0 +1,54 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// 评价索引的mapping
+// canal消息中的字段值都是字符串，动态mapping会把id、评分、时间都映射成text，不能排序和范围查询
+// 评价索引由review-job在启动时按这里的mapping创建，写入时字符串会被转换成对应的数字和日期类型
+// review-service按create_at、review_id倒序翻页，两个字段都必须是数字类型
+const reviewIndexMapping = `{
+  "mappings": {
+    "properties": {
+      "review_id":     {"type": "long"},
+      "order_id":      {"type": "long"},
+      "sku_id":        {"type": "long"},
+      "spu_id":        {"type": "long"},
+      "store_id":      {"type": "long"},
+      "user_id":       {"type": "long"},
+      "version":       {"type": "integer"},
+      "score":         {"type": "integer"},
+      "service_score": {"type": "integer"},
+      "express_score": {"type": "integer"},
+      "has_media":     {"type": "integer"},
+      "anonymous":     {"type": "integer"},
+      "status":        {"type": "integer"},
+      "is_default":    {"type": "integer"},
+      "has_reply":     {"type": "integer"},
+      "content":       {"type": "text"},
+      "create_at":     {"type": "date", "format": "yyyy-MM-dd HH:mm:ss||strict_date_optional_time||epoch_millis"},
+      "update_at":     {"type": "date", "format": "yyyy-MM-dd HH:mm:ss||strict_date_optional_time||epoch_millis"},
+      "delete_at":     {"type": "date", "format": "yyyy-MM-dd HH:mm:ss||strict_date_optional_time||epoch_millis"},
+      "sync_version":  {"type": "object", "enabled": false}
+    }
+  }
+}`
+
+// ensureIndex 评价索引不存在时按mapping创建，已经存在的索引不修改
+// 已有的动态mapping索引需要按新的mapping重建索引后再切换
+func (es *ESClient) ensureIndex(ctx context.Context) error {
+	exists, err := es.Indices.Exists(es.index).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check index:%s, err:%w", es.index, err)
+	}
+	if exists {
+		return nil
+	}
+	if _, err := es.Indices.Create(es.index).Raw(strings.NewReader(reviewIndexMapping)).Do(ctx); err != nil {
+		return fmt.Errorf("failed to create index:%s, err:%w", es.index, err)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package job
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// review-service按create_at、review_id排序翻页，mapping中必须是日期和数字类型
+func TestReviewIndexMapping(t *testing.T) {
+	m := struct {
+		Mappings struct {
+			Properties map[string]struct {
+				Type string `json:"type"`
+			} `json:"properties"`
+		} `json:"mappings"`
+	}{}
+	if err := json.Unmarshal([]byte(reviewIndexMapping), &m); err != nil {
+		t.Fatalf("invalid mapping: %v", err)
+	}
+	for field, want := range map[string]string{"review_id": "long", "store_id": "long", "create_at": "date", "delete_at": "date"} {
+		if got := m.Mappings.Properties[field].Type; got != want {
+			t.Fatalf("%s type = %q, want %q", field, got, want)
+		}
+	}
+}
@@ -2,4 +2,4 @@ package job
 
 import "github.com/google/wire"
 
-var ProviderSet = wire.NewSet(NewJobWorker, NewESClient, NewKafkaReader)
+var ProviderSet = wire.NewSet(NewJobWorker, NewESClient, NewKafkaReader, NewDLQWriter, NewAlerter, NewDefaultReviewWorker, NewOrderSource, NewAppealSLAWorker)
@@ -0,0 +1,216 @@
+package job
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// 追评、商家回复、申诉合并到评价文档
+// 追评、回复和申诉写到评价文档的follow_up、reply、appeal字段中，店铺的评价列表直接从ES中取，不用再查MySQL
+// 不同表的消息可能在不同的分区，到达顺序和MySQL中的修改顺序不一定一致，所以合并时按版本号判断：
+// 评价文档的sync_version字段记录每个字段最后一次合并的版本，收到更旧或者相同版本的消息时不修改文档
+// 评价删除后文档保留（只写入delete_at），所以文档不存在只可能是评价的消息还没到：
+// 这时用空文档执行脚本创建一个只有合并字段的文档，评价的消息到达后把评价的字段补进去
+
+// syncScript 按版本号判断消息是否比文档中已经同步的更新，更旧或者相同版本时不修改文档
+// params.field是sync_version中的key，版本号是多个数字时按顺序逐个比较
+const syncScript = `
+if (ctx._source.sync_version == null) {
+	ctx._source.sync_version = new HashMap();
+}
+def cur = ctx._source.sync_version[params.field];
+if (cur != null) {
+	for (int i = 0; i < params.seq.size(); i++) {
+		long a = i < cur.size() ? ((Number) cur[i]).longValue() : -1;
+		long b = ((Number) params.seq[i]).longValue();
+		if (a > b) {
+			ctx.op = 'noop';
+			return;
+		}
+		if (a < b) {
+			break;
+		}
+		if (i == params.seq.size() - 1) {
+			ctx.op = 'noop';
+			return;
+		}
+	}
+}
+ctx._source.sync_version[params.field] = params.seq;
+`
+
+// mergeScript 按版本号把params.value写到评价文档的params.field字段
+const mergeScript = syncScript + `ctx._source[params.field] = params.value;`
+
+// reviewScript 按版本号把评价表的行合并到评价文档的顶层字段，保留已经合并进来的追评、回复、申诉
+const reviewScript = syncScript + `ctx._source.putAll(params.value);`
+
+// 评价文档中合并的字段，fieldReview是评价表本身在sync_version中的key
+const (
+	fieldReview   = "review"
+	fieldFollowUp = "follow_up"
+	fieldReply    = "reply"
+	fieldAppeal   = "appeal"
+)
+
+// 合并到评价文档中的追评、回复、申诉字段，canal消息中的值都是字符串，原样写入
+var (
+	followUpFields = []string{"follow_up_id", "content", "has_media", "pic_info", "video_info", "status", "create_at", "update_at"}
+	replyFields    = []string{"reply_id", "content", "pic_info", "video_info", "create_at", "update_at"}
+	appealFields   = []string{"appeal_id", "status", "reason", "submit_count", "deadline_at", "create_at", "update_at"}
+)
+
+// newMergeOp 按版本号把value合并到评价文档的field字段，value为nil时清空字段
+func newMergeOp(reviewID, field string, seq []int64, value map[string]interface{}) (*bulkOp, error) {
+	return newSyncOp(reviewID, mergeScript, field, seq, value)
+}
+
+// newSyncOp 执行按版本号同步的脚本，文档不存在时用空文档执行脚本创建
+// 清空字段时也要创建，sync_version记下删除的版本，之后才到达的旧消息不会把字段写回来
+func newSyncOp(reviewID, script, field string, seq []int64, value map[string]interface{}) (*bulkOp, error) {
+	return newBulkOp(bulkUpdate, reviewID, map[string]interface{}{
+		"scripted_upsert": true,
+		"script": map[string]interface{}{
+			"source": script,
+			"params": map[string]interface{}{
+				"field": field,
+				"seq":   seq,
+				"value": value,
+			},
+		},
+		"upsert": map[string]interface{}{},
+	})
+}
+
+// followUpOp 追评表的变更：新增和修改时更新评价文档的follow_up字段，删除时清空
+// 追评只会逻辑删除，和回复一样按update_at、follow_up_id、version判断新旧
+func followUpOp(msgType string, row map[string]interface{}) (*bulkOp, error) {
+	reviewID, err := getReviewID(row)
+	if err != nil {
+		return nil, err
+	}
+	updateAt, err := getUnix(row, "update_at")
+	if err != nil {
+		return nil, err
+	}
+	followUpID, err := getInt(row, "follow_up_id")
+	if err != nil {
+		return nil, err
+	}
+	version, err := getInt(row, "version")
+	if err != nil {
+		return nil, err
+	}
+	switch msgType {
+	case msgTypeInsert, msgTypeUpdate:
+		if isDeleted(row) {
+			return newMergeOp(reviewID, fieldFollowUp, []int64{updateAt, followUpID, version}, nil)
+		}
+		return newMergeOp(reviewID, fieldFollowUp, []int64{updateAt, followUpID, version}, pick(row, followUpFields))
+	case msgTypeDelete:
+		return newMergeOp(reviewID, fieldFollowUp, []int64{updateAt, followUpID, version + 1}, nil)
+	default:
+		return nil, fmt.Errorf("%w: unsupported type:%s", errMalformedMessage, msgType)
+	}
+}
+
+// replyOp 回复表的变更：新增和修改时更新评价文档的reply字段，删除时清空
+// 商家撤回回复是物理删除，之后可以重新回复，新回复的version从0开始，不能只按version判断新旧
+// 先按update_at（MySQL中的修改时间，重新回复不会比撤回前更早），同一秒内再按reply_id（雪花id，新回复更大），最后按version
+func replyOp(msgType string, row map[string]interface{}) (*bulkOp, error) {
+	reviewID, err := getReviewID(row)
+	if err != nil {
+		return nil, err
+	}
+	updateAt, err := getUnix(row, "update_at")
+	if err != nil {
+		return nil, err
+	}
+	replyID, err := getInt(row, "reply_id")
+	if err != nil {
+		return nil, err
+	}
+	version, err := getInt(row, "version")
+	if err != nil {
+		return nil, err
+	}
+	switch msgType {
+	case msgTypeInsert, msgTypeUpdate:
+		if isDeleted(row) {
+			return newMergeOp(reviewID, fieldReply, []int64{updateAt, replyID, version}, nil)
+		}
+		return newMergeOp(reviewID, fieldReply, []int64{updateAt, replyID, version}, pick(row, replyFields))
+	case msgTypeDelete:
+		// 物理删除的消息是删除前的数据，不会增加version和update_at，按比删除前更新的版本处理
+		return newMergeOp(reviewID, fieldReply, []int64{updateAt, replyID, version + 1}, nil)
+	default:
+		return nil, fmt.Errorf("%w: unsupported type:%s", errMalformedMessage, msgType)
+	}
+}
+
+// appealOp 申诉表的变更：评价文档的appeal字段只保存最新一次申诉
+// 被驳回后重新申诉会新建一条申诉，先按第几次提交、再按version判断新旧，旧申诉的消息不会覆盖新申诉
+func appealOp(msgType string, row map[string]interface{}) (*bulkOp, error) {
+	reviewID, err := getReviewID(row)
+	if err != nil {
+		return nil, err
+	}
+	submitCount, err := getInt(row, "submit_count")
+	if err != nil {
+		return nil, err
+	}
+	version, err := getInt(row, "version")
+	if err != nil {
+		return nil, err
+	}
+	switch msgType {
+	case msgTypeInsert, msgTypeUpdate:
+		if isDeleted(row) {
+			return newMergeOp(reviewID, fieldAppeal, []int64{submitCount, version}, nil)
+		}
+		return newMergeOp(reviewID, fieldAppeal, []int64{submitCount, version}, pick(row, appealFields))
+	case msgTypeDelete:
+		return newMergeOp(reviewID, fieldAppeal, []int64{submitCount, version + 1}, nil)
+	default:
+		return nil, fmt.Errorf("%w: unsupported type:%s", errMalformedMessage, msgType)
+	}
+}
+
+// pick 取出行中需要写入评价文档的字段
+func pick(row map[string]interface{}, fields []string) map[string]interface{} {
+	ret := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		ret[f] = row[f]
+	}
+	return ret
+}
+
+// canalTimeLayout canal消息中datetime、timestamp字段的格式
+const canalTimeLayout = "2006-01-02 15:04:05"
+
+// getUnix 把canal消息中的时间字段转换成秒级时间戳，只用来比较先后，按UTC解析即可
+func getUnix(row map[string]interface{}, field string) (int64, error) {
+	s, ok := row[field].(string)
+	if !ok {
+		return 0, fmt.Errorf("%w: missing %s", errMalformedMessage, field)
+	}
+	t, err := time.Parse(canalTimeLayout, s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid %s:%s", errMalformedMessage, field, s)
+	}
+	return t.Unix(), nil
+}
+
+// getInt canal消息中的数字字段也是字符串
+func getInt(row map[string]interface{}, field string) (int64, error) {
+	s, ok := row[field].(string)
+	if !ok {
+		return 0, fmt.Errorf("%w: missing %s", errMalformedMessage, field)
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid %s:%s", errMalformedMessage, field, s)
+	}
+	return n, nil
+}
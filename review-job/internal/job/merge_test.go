@@ -0,0 +1,212 @@
+package job
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+type mergeBody struct {
+	Script struct {
+		Source string `json:"source"`
+		Params struct {
+			Field string                 `json:"field"`
+			Seq   []int64                `json:"seq"`
+			Value map[string]interface{} `json:"value"`
+		} `json:"params"`
+	} `json:"script"`
+	Upsert         json.RawMessage `json:"upsert"`
+	ScriptedUpsert bool            `json:"scripted_upsert"`
+}
+
+// parseMergeBody 同步操作都是用空文档执行脚本的scripted upsert，文档不存在时也由脚本判断版本号
+func parseMergeBody(t *testing.T, op *bulkOp, script string) *mergeBody {
+	t.Helper()
+	body := &mergeBody{}
+	if err := json.Unmarshal(op.body, body); err != nil {
+		t.Fatal(err)
+	}
+	if op.kind != bulkUpdate || !body.ScriptedUpsert || string(body.Upsert) != "{}" {
+		t.Fatalf("op %s should be a scripted upsert with empty document: %s", op.kind, op.body)
+	}
+	if body.Script.Source != script {
+		t.Fatalf("script = %s, want %s", body.Script.Source, script)
+	}
+	return body
+}
+
+// wantParams 校验脚本参数，版本号在脚本中按顺序逐个比较
+func wantParams(t *testing.T, body *mergeBody, field string, seq []int64) {
+	t.Helper()
+	p := body.Script.Params
+	if p.Field != field || fmt.Sprint(p.Seq) != fmt.Sprint(seq) {
+		t.Fatalf("params field:%s seq:%v, want field:%s seq:%v", p.Field, p.Seq, field, seq)
+	}
+}
+
+func replyRow(replyID, version, updateAt string) map[string]interface{} {
+	return map[string]interface{}{
+		"review_id": "1",
+		"reply_id":  replyID,
+		"version":   version,
+		"content":   "谢谢",
+		"create_at": updateAt,
+		"update_at": updateAt,
+	}
+}
+
+// 撤回回复后重新回复，新回复的version从0开始，按update_at、reply_id仍然比撤回的消息新
+func TestReplyOpRecreate(t *testing.T) {
+	tests := []struct {
+		name         string
+		newAt        string
+		wantRecreate []int64
+	}{
+		{name: "same second", newAt: "2026-10-18 12:00:00", wantRecreate: []int64{1792324800, 101, 0}},
+		{name: "later", newAt: "2026-10-18 12:00:01", wantRecreate: []int64{1792324801, 101, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update, err := replyOp(msgTypeUpdate, replyRow("100", "3", "2026-10-18 12:00:00"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			withdraw, err := replyOp(msgTypeDelete, replyRow("100", "3", "2026-10-18 12:00:00"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			recreate, err := replyOp(msgTypeInsert, replyRow("101", "0", tt.newAt))
+			if err != nil {
+				t.Fatal(err)
+			}
+			u := parseMergeBody(t, update, mergeScript)
+			wantParams(t, u, fieldReply, []int64{1792324800, 100, 3})
+			// 物理删除的消息是删除前的数据，版本号加1才比最后一次修改新
+			w := parseMergeBody(t, withdraw, mergeScript)
+			wantParams(t, w, fieldReply, []int64{1792324800, 100, 4})
+			r := parseMergeBody(t, recreate, mergeScript)
+			wantParams(t, r, fieldReply, tt.wantRecreate)
+			if w.Script.Params.Value != nil || r.Script.Params.Value["reply_id"] != "101" {
+				t.Fatalf("withdraw value:%v recreate value:%v", w.Script.Params.Value, r.Script.Params.Value)
+			}
+		})
+	}
+}
+
+// 追评只合并追评的字段，不带canal行中的op_user、ctrl_json等内部字段；逻辑删除时清空
+func TestFollowUpOp(t *testing.T) {
+	row := map[string]interface{}{
+		"review_id":    "1",
+		"follow_up_id": "200",
+		"version":      "1",
+		"content":      "用了一个月还不错",
+		"has_media":    "0",
+		"pic_info":     "",
+		"video_info":   "",
+		"status":       "20",
+		"op_user":      "system",
+		"ctrl_json":    `{"audit":"auto"}`,
+		"create_at":    "2026-10-18 12:00:00",
+		"update_at":    "2026-10-18 12:00:00",
+		"delete_at":    nil,
+	}
+	op, err := followUpOp(msgTypeInsert, row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := parseMergeBody(t, op, mergeScript)
+	wantParams(t, body, fieldFollowUp, []int64{1792324800, 200, 1})
+	want := map[string]interface{}{
+		"follow_up_id": "200",
+		"content":      "用了一个月还不错",
+		"has_media":    "0",
+		"pic_info":     "",
+		"video_info":   "",
+		"status":       "20",
+		"create_at":    "2026-10-18 12:00:00",
+		"update_at":    "2026-10-18 12:00:00",
+	}
+	if fmt.Sprint(body.Script.Params.Value) != fmt.Sprint(want) {
+		t.Fatalf("value = %v, want %v", body.Script.Params.Value, want)
+	}
+
+	// 删除评价时级联逻辑删除的追评
+	row["version"] = "2"
+	row["delete_at"] = "2026-10-18 12:00:00"
+	op, err = followUpOp(msgTypeUpdate, row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body = parseMergeBody(t, op, mergeScript)
+	wantParams(t, body, fieldFollowUp, []int64{1792324800, 200, 2})
+	if body.Script.Params.Value != nil {
+		t.Fatalf("deleted follow-up value = %v, want nil", body.Script.Params.Value)
+	}
+}
+
+func TestReplyOpMalformed(t *testing.T) {
+	row := replyRow("100", "0", "2026/10/18")
+	if _, err := replyOp(msgTypeInsert, row); err == nil {
+		t.Fatal("replyOp with invalid update_at should fail")
+	}
+}
+
+// 评价表的变更按update_at、version合并，删除评价只写入delete_at，文档保留下来挡住删除之后到达的旧消息
+func TestReviewOp(t *testing.T) {
+	row := func(version, updateAt, deleteAt string) map[string]interface{} {
+		r := map[string]interface{}{
+			"review_id": "1",
+			"store_id":  "21",
+			"version":   version,
+			"update_at": updateAt,
+			"delete_at": nil,
+		}
+		if len(deleteAt) > 0 {
+			r["delete_at"] = deleteAt
+		}
+		return r
+	}
+	tests := []struct {
+		name      string
+		msgType   string
+		row       map[string]interface{}
+		wantSeq   []int64
+		wantValue func(map[string]interface{}) bool
+	}{
+		{
+			name: "insert", msgType: msgTypeInsert, row: row("0", "2026-10-18 12:00:00", ""),
+			wantSeq:   []int64{1792324800, 0},
+			wantValue: func(v map[string]interface{}) bool { return v["store_id"] == "21" && v["delete_at"] == nil },
+		},
+		{
+			name: "soft delete", msgType: msgTypeUpdate, row: row("3", "2026-10-18 12:00:05", "2026-10-18 12:00:05"),
+			wantSeq:   []int64{1792324805, 3},
+			wantValue: func(v map[string]interface{}) bool { return v["delete_at"] == "2026-10-18 12:00:05" },
+		},
+		{
+			name: "hard delete", msgType: msgTypeDelete, row: row("3", "2026-10-18 12:00:05", ""),
+			wantSeq: []int64{1792324805, 4},
+			wantValue: func(v map[string]interface{}) bool {
+				_, ok := v["delete_at"].(string)
+				return ok && len(v) == 1
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := reviewOp(tt.msgType, tt.row)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if op.id != "1" {
+				t.Fatalf("op id:%s, want 1", op.id)
+			}
+			body := parseMergeBody(t, op, reviewScript)
+			wantParams(t, body, fieldReview, tt.wantSeq)
+			p := body.Script.Params
+			if !tt.wantValue(p.Value) {
+				t.Fatalf("value = %v", p.Value)
+			}
+		})
+	}
+}
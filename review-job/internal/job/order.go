@@ -0,0 +1,85 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"review-job/internal/conf"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Order 已完成的订单
+type Order struct {
+	OrderID    int64
+	UserID     int64
+	StoreID    int64
+	Items      []*OrderItem
+	CompleteAt time.Time
+}
+
+// OrderItem 订单中的商品
+type OrderItem struct {
+	SkuID int64
+	SpuID int64
+}
+
+// OrderSource 订单数据来源
+type OrderSource interface {
+	// ListCompletedOrders 按订单ID升序分批查询完成时间在[start, end)之间的订单，afterOrderID为上一批最后一个订单ID
+	ListCompletedOrders(ctx context.Context, start, end time.Time, afterOrderID int64, limit int) ([]*Order, error)
+}
+
+// 订单数据来源配置 default_review.order_source
+const orderSourceLocal = "local"
+
+// NewOrderSource 按配置选择订单数据来源
+// 订单服务还没有接入，只有显式配置为local时才使用内存中的订单数据；没有配置时返回nil，默认评价任务不执行
+func NewOrderSource(cfg *conf.DefaultReview) (OrderSource, error) {
+	switch cfg.GetOrderSource() {
+	case "":
+		return nil, nil
+	case orderSourceLocal:
+		return NewLocalOrderSource(), nil
+	default:
+		return nil, fmt.Errorf("unsupported order source:%s", cfg.GetOrderSource())
+	}
+}
+
+// LocalOrderSource 基于内存的订单数据，用于本地开发和测试
+type LocalOrderSource struct {
+	mu     sync.RWMutex
+	orders []*Order
+}
+
+func NewLocalOrderSource(orders ...*Order) *LocalOrderSource {
+	s := &LocalOrderSource{}
+	s.Add(orders...)
+	return s
+}
+
+// Add 添加订单
+func (s *LocalOrderSource) Add(orders ...*Order) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orders = append(s.orders, orders...)
+	sort.Slice(s.orders, func(i, j int) bool {
+		return s.orders[i].OrderID < s.orders[j].OrderID
+	})
+}
+
+func (s *LocalOrderSource) ListCompletedOrders(ctx context.Context, start, end time.Time, afterOrderID int64, limit int) ([]*Order, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ret := make([]*Order, 0, limit)
+	for _, o := range s.orders {
+		if len(ret) >= limit {
+			break
+		}
+		if o.OrderID <= afterOrderID || o.CompleteAt.Before(start) || !o.CompleteAt.Before(end) {
+			continue
+		}
+		ret = append(ret, o)
+	}
+	return ret, nil
+}
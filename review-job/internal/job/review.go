@@ -3,12 +3,16 @@ package job
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/elastic/go-elasticsearch/v8"
-	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/segmentio/kafka-go"
+	"io"
+	"net/http"
 	"review-job/internal/conf"
+	"time"
 )
 
 // 评价数据流处理
@@ -19,15 +23,47 @@ import (
 type JobWorker struct {
 	kafkaReader *kafka.Reader
 	esClient    *ESClient
+	dlqWriter   *kafka.Writer
+	alerter     *Alerter
 	log         *log.Helper
+	batch       *bulkBatch // 正在攒的一批消息，只在Start所在的goroutine中使用
+
+	database        string        // 只处理这个库的变更消息，为空时不过滤
+	retryBackoff    time.Duration // 第一次重试的等待时间
+	maxRetryBackoff time.Duration // 重试等待时间的上限
+	maxAttempts     int           // 一条消息最多处理的次数，超过后写入死信队列
 }
 
-func NewJobWorker(kafkaReader *kafka.Reader, esClient *ESClient, logger log.Logger) *JobWorker {
-	return &JobWorker{
+const (
+	defaultRetryBackoff    = time.Second
+	defaultMaxRetryBackoff = 30 * time.Second
+	defaultMaxAttempts     = 5
+)
+
+func NewJobWorker(kafkaReader *kafka.Reader, esClient *ESClient, dlqWriter *kafka.Writer, alerter *Alerter, cfg *conf.Kafka, logger log.Logger) *JobWorker {
+	job := &JobWorker{
 		kafkaReader: kafkaReader,
 		esClient:    esClient,
+		dlqWriter:   dlqWriter,
+		alerter:     alerter,
 		log:         log.NewHelper(logger),
+		batch:       new(bulkBatch),
+
+		database:        cfg.GetDatabase(),
+		retryBackoff:    cfg.GetRetryBackoff().AsDuration(),
+		maxRetryBackoff: cfg.GetMaxRetryBackoff().AsDuration(),
+		maxAttempts:     int(cfg.GetMaxAttempts()),
 	}
+	if job.maxAttempts <= 0 {
+		job.maxAttempts = defaultMaxAttempts
+	}
+	if job.retryBackoff <= 0 {
+		job.retryBackoff = defaultRetryBackoff
+	}
+	if job.maxRetryBackoff < job.retryBackoff {
+		job.maxRetryBackoff = defaultMaxRetryBackoff
+	}
+	return job
 }
 
 func NewKafkaReader(cfg *conf.Kafka) *kafka.Reader {
@@ -44,15 +80,32 @@ func NewESClient(cfg *conf.Elasticsearch) (*ESClient, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &ESClient{
-		index:       cfg.Index,
-		TypedClient: client,
-	}, nil
+	es := &ESClient{
+		index:         cfg.Index,
+		TypedClient:   client,
+		maxActions:    int(cfg.GetBulk().GetMaxActions()),
+		maxBytes:      int(cfg.GetBulk().GetMaxBytes()),
+		flushInterval: cfg.GetBulk().GetFlushInterval().AsDuration(),
+	}
+	if es.maxActions <= 0 {
+		es.maxActions = defaultBulkMaxActions
+	}
+	if es.maxBytes <= 0 {
+		es.maxBytes = defaultBulkMaxBytes
+	}
+	if es.flushInterval <= 0 {
+		es.flushInterval = defaultBulkFlushInterval
+	}
+	return es, nil
 }
 
 type ESClient struct {
 	*elasticsearch.TypedClient
 	index string
+
+	maxActions    int           // 每批最多的bulk操作数
+	maxBytes      int           // 每批bulk请求体的最大字节数
+	flushInterval time.Duration // 批次中第一条消息最多等待的时间
 }
 
 type Msg struct {
@@ -60,67 +113,209 @@ type Msg struct {
 	Database string                   `json:"database"`
 	Table    string                   `json:"table"`
 	IsDdl    bool                     `json:"isddl"`
+	Sql      string                   `json:"sql"`
 	Data     []map[string]interface{} `json:"data"`
 }
 
 // Start 程序启动后干活的
+// 消息攒成一批通过_bulk写入ES，使用FetchMessage手动提交offset，整批消息写入ES或者写入死信队列后才提交
+// 服务重启或者重平衡后未提交的消息会重新消费
 func (job *JobWorker) Start(ctx context.Context) error {
 	// 1.从kafka中获取MySQL中的数据变更消息
 	job.log.Debugf("start job worker.....")
+	// 索引不存在时先按mapping创建，避免第一条写入按动态mapping创建索引
+	if err := job.esClient.ensureIndex(ctx); err != nil {
+		return err
+	}
+	backoff := job.retryBackoff
 	for {
-		m, err := job.kafkaReader.ReadMessage(ctx)
-		if errors.Is(err, context.Canceled) {
+		m, err := job.fetchMessage(ctx)
+		// ctx取消或者reader被关闭，正常退出，未写入的批次不提交offset
+		if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, io.EOF) {
 			return nil
 		}
-		if err != nil {
-			job.log.Errorf("failed to read message: %v", err)
-			break
+		// 批次等待时间到了，没攒满也写入
+		if errors.Is(err, context.DeadlineExceeded) {
+			if err := job.flush(ctx); err != nil {
+				return nil
+			}
+			continue
 		}
-		fmt.Printf("message at topic/partition/offset %v/%v/%v:%s = %s \n", m.Topic, m.Partition, m.Offset, string(m.Key), string(m.Value))
-
-		msg := new(Msg)
-		err = json.Unmarshal(m.Value, msg)
 		if err != nil {
-			job.log.Errorf("failed to unmarshal message: %v", err)
+			// 读取失败不退出，等一会儿继续读
+			job.log.Errorf("failed to fetch message: %v, retry after %v", err, backoff)
+			if !sleepCtx(ctx, backoff) {
+				return nil
+			}
+			backoff = job.nextBackoff(backoff)
 			continue
 		}
+		backoff = job.retryBackoff
+		fmt.Printf("message at topic/partition/offset %v/%v/%v:%s = %s \n", m.Topic, m.Partition, m.Offset, string(m.Key), string(m.Value))
 
-		if msg.Type == "INSERT" {
-			// 新增文档到Es
-			for idx := range msg.Data {
-				job.IndexDocument(msg.Data[idx])
-			}
-		} else {
-			// 更新 文档
-			for idx := range msg.Data {
-				job.updateDocument(msg.Data[idx])
-
+		// 2.将完整的评价数据写入ES
+		if err := job.add(ctx, m); err != nil {
+			return nil
+		}
+		if job.full() {
+			if err := job.flush(ctx); err != nil {
+				return nil
 			}
 		}
 	}
-	// 2.将完整的评价数据写入ES
-	return nil
 }
 
-func (job *JobWorker) IndexDocument(doc map[string]interface{}) {
-	reviewID := doc["review_id"].(string)
+// fetchMessage 批次中已经有消息时，最多等到批次的写入时间
+func (job *JobWorker) fetchMessage(ctx context.Context) (kafka.Message, error) {
+	if job.batch.empty() {
+		return job.kafkaReader.FetchMessage(ctx)
+	}
+	fetchCtx, cancel := context.WithDeadline(ctx, job.batch.deadline)
+	defer cancel()
+	return job.kafkaReader.FetchMessage(fetchCtx)
+}
 
-	resp, err := job.esClient.Index(job.esClient.index).Id(reviewID).Document(doc).Do(context.Background())
-	if err != nil {
-		job.log.Errorf("failed to index document: %v", err)
-		return
+// canal消息类型
+const (
+	msgTypeInsert = "INSERT"
+	msgTypeUpdate = "UPDATE"
+	msgTypeDelete = "DELETE"
+)
+
+// 需要同步到ES评价文档的表
+const (
+	tableReview   = "review_info"
+	tableFollowUp = "review_follow_up_info"
+	tableReply    = "review_reply_info"
+	tableAppeal   = "review_appeal_info"
+)
+
+// buildOps 解析canal消息，按表和消息类型转换成bulk操作
+// 操作都是按review_id覆盖或者更新，重试或者重新消费时重复写入不影响结果
+func (job *JobWorker) buildOps(ctx context.Context, m kafka.Message) ([]*bulkOp, error) {
+	msg := new(Msg)
+	if err := json.Unmarshal(m.Value, msg); err != nil {
+		return nil, fmt.Errorf("%w: %v", errMalformedMessage, err)
 	}
-	job.log.Debugf("document indexed: %v", resp)
+	// 表结构变更需要人工确认ES的mapping是否要调整，不写ES
+	if msg.IsDdl {
+		job.alerter.Alert(ctx, "收到DDL消息 %s.%s partition/offset %v/%v: %s",
+			msg.Database, msg.Table, m.Partition, m.Offset, msg.Sql)
+		return nil, nil
+	}
+	if len(job.database) > 0 && msg.Database != job.database {
+		job.log.Debugf("skip message of database:%s table:%s", msg.Database, msg.Table)
+		return nil, nil
+	}
+
+	var build func(msgType string, row map[string]interface{}) (*bulkOp, error)
+	switch msg.Table {
+	case tableReview:
+		build = reviewOp
+	case tableFollowUp:
+		// 追评、商家回复、申诉按版本号合并到对应的评价文档中
+		build = followUpOp
+	case tableReply:
+		build = replyOp
+	case tableAppeal:
+		build = appealOp
+	default:
+		// 其他表的行没有评价文档需要的字段，不能写到评价文档中
+		job.log.Debugf("skip message of table:%s", msg.Table)
+		return nil, nil
+	}
+	ops := make([]*bulkOp, 0, len(msg.Data))
+	for _, row := range msg.Data {
+		op, err := build(msg.Type, row)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
 }
 
-func (job *JobWorker) updateDocument(doc map[string]interface{}) {
-	reviewID := doc["review_id"].(string)
-	resp, err := job.esClient.Update(job.esClient.index, reviewID).Doc(doc).Do(context.Background())
+// reviewOp 评价表的变更：按update_at、version判断新旧后把整行合并到评价文档，文档不存在时创建
+// 重放死信队列或者重复投递的旧消息不会覆盖更新的数据，也不会覆盖已经合并进来的追评、回复、申诉字段
+// 删除评价时不删除文档，只写入delete_at，店铺评价列表按delete_at过滤；
+// 文档和sync_version保留下来，删除之后才到达的旧消息按版本号判断是旧数据，不会把评价重新写回索引
+func reviewOp(msgType string, row map[string]interface{}) (*bulkOp, error) {
+	reviewID, err := getReviewID(row)
+	if err != nil {
+		return nil, err
+	}
+	updateAt, err := getUnix(row, "update_at")
+	if err != nil {
+		return nil, err
+	}
+	version, err := getInt(row, "version")
 	if err != nil {
-		job.log.Errorf("failed to update document: %v", err)
-		return
+		return nil, err
+	}
+	switch msgType {
+	case msgTypeInsert, msgTypeUpdate:
+		// 逻辑删除的行带着delete_at，和修改一样合并
+		return newSyncOp(reviewID, reviewScript, fieldReview, []int64{updateAt, version}, row)
+	case msgTypeDelete:
+		// 物理删除的消息是删除前的数据，按比删除前更新的版本处理，删除时间取处理消息的时间
+		return newSyncOp(reviewID, reviewScript, fieldReview, []int64{updateAt, version + 1}, map[string]interface{}{
+			"delete_at": time.Now().Format(canalTimeLayout),
+		})
+	default:
+		return nil, fmt.Errorf("%w: unsupported type:%s", errMalformedMessage, msgType)
+	}
+}
+
+// isDeleted canal消息中delete_at不为空说明数据已被逻辑删除
+func isDeleted(doc map[string]interface{}) bool {
+	deleteAt, ok := doc["delete_at"].(string)
+	return ok && len(deleteAt) > 0
+}
+
+// getReviewID canal消息中的字段值都是字符串
+func getReviewID(doc map[string]interface{}) (string, error) {
+	reviewID, ok := doc["review_id"].(string)
+	if !ok || len(reviewID) == 0 {
+		return "", fmt.Errorf("%w: missing review_id", errMalformedMessage)
+	}
+	return reviewID, nil
+}
+
+// errMalformedMessage 消息不是合法的canal消息
+var errMalformedMessage = errors.New("malformed message")
+
+// isRetryable 网络错误、ES返回429或5xx时是临时故障，重试可能成功
+// 消息格式错误和ES返回的其他4xx错误（如字段类型不匹配）重试也不会成功
+func isRetryable(err error) bool {
+	if errors.Is(err, errMalformedMessage) {
+		return false
+	}
+	var esErr *types.ElasticsearchError
+	if errors.As(err, &esErr) {
+		return esErr.Status == http.StatusTooManyRequests || esErr.Status >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// nextBackoff 重试间隔指数增长，不超过配置的最大间隔
+func (job *JobWorker) nextBackoff(d time.Duration) time.Duration {
+	d *= 2
+	if d > job.maxRetryBackoff {
+		d = job.maxRetryBackoff
+	}
+	return d
+}
+
+// sleepCtx 等待d，ctx取消时提前返回false
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
 	}
-	job.log.Debugf("document updated to %v\n", resp.Result)
 }
 
 // Stop kratos结束后调用的
@@ -23,15 +23,16 @@ import (
 // Injectors from wire.go:
 
 // wireApp init kratos application.
-func wireApp(confServer *conf.Server, registry *conf.Registry, confData *conf.Data, logger log.Logger) (*kratos.App, func(), error) {
+func wireApp(confServer *conf.Server, registry *conf.Registry, confData *conf.Data, task *conf.Task, logger log.Logger) (*kratos.App, func(), error) {
 	discovery := data.NewDiscovery(registry)
 	reviewClient := data.NewReviewServiceClient(discovery)
-	dataData, cleanup, err := data.NewData(confData, reviewClient, logger)
+	client := data.NewRedisClient(confData)
+	dataData, cleanup, err := data.NewData(confData, reviewClient, client, logger)
 	if err != nil {
 		return nil, nil, err
 	}
 	operationRepo := data.NewOperationRepo(dataData, logger)
-	operationUsecase := biz.NewOperationUsecase(operationRepo, logger)
+	operationUsecase := biz.NewOperationUsecase(operationRepo, task, logger)
 	operationService := service.NewOperationService(operationUsecase)
 	grpcServer := server.NewGRPCServer(confServer, operationService, logger)
 	httpServer := server.NewHTTPServer(confServer, operationService, logger)
@@ -0,0 +1,31 @@
+package biz
+
+import (
+	"context"
+)
+
+// AppealInfo 申诉信息
+type AppealInfo struct {
+	AppealID    int64
+	ReviewID    int64
+	StoreID     int64
+	Status      int32
+	Reason      string
+	Content     string
+	PicInfo     string
+	VideoInfo   string
+	SubmitCount int32
+	OpReason    string
+	OpRemarks   string
+	Version     int32
+	DeadlineAt  string // 审核截止时间
+	SLAStatus   int32  // 审核时效状态：0已处理；1未超时；2已超时；3已升级
+	CreateAt    string
+	UpdateAt    string
+}
+
+// ListEscalatedAppeals 高级审核队列：超过审核截止时间仍未审核的申诉，按截止时间先后排序
+func (uc *OperationUsecase) ListEscalatedAppeals(ctx context.Context, page, size int32) ([]*AppealInfo, error) {
+	uc.log.WithContext(ctx).Infof("ListEscalatedAppeals,page:%v,size:%v", page, size)
+	return uc.repo.ListEscalatedAppeals(ctx, page, size)
+}
@@ -0,0 +1,121 @@
+package biz
+
+import (
+	"context"
+	v1 "review-o/api/operation/v1"
+)
+
+// maxBatchAuditSize 批量审核一次最多处理的数量，和review-service保持一致
+const maxBatchAuditSize = 100
+
+// BatchAuditReviewParam 批量审核评价的参数，所有评价使用同一个审核结果
+type BatchAuditReviewParam struct {
+	ReviewIDs []int64
+	Status    int
+	OpReason  string
+	OpRemarks string
+	OpUser    string
+}
+
+// BatchAuditAppealParam 批量审核申诉的参数，所有申诉使用同一个审核结果
+type BatchAuditAppealParam struct {
+	AppealIDs []int64
+	Status    int
+	OpReason  string
+	OpRemarks string
+	OpUser    string
+}
+
+// BatchAuditResult 批量审核中单条的结果
+type BatchAuditResult struct {
+	ID  int64
+	Err error // 为nil表示审核成功
+}
+
+// BatchAuditReview 批量审核评价
+// 和单条审核一样要求运营持有任务租约，没有租约的评价直接返回失败，其余交给review-service逐条审核
+func (uc *OperationUsecase) BatchAuditReview(ctx context.Context, param *BatchAuditReviewParam) ([]*BatchAuditResult, error) {
+	uc.log.WithContext(ctx).Infof("BatchAuditReview,param:%v", param)
+	results, claimed, err := uc.checkBatchTaskLease(ctx, TaskTypeReview, param.ReviewIDs, param.OpUser)
+	if err != nil {
+		return nil, err
+	}
+	if len(claimed) == 0 {
+		return results, nil
+	}
+	p := *param
+	p.ReviewIDs = claimed
+	ret, err := uc.repo.BatchAuditReview(ctx, &p)
+	if err != nil {
+		return nil, err
+	}
+	uc.mergeBatchResults(ctx, TaskTypeReview, param.OpUser, results, ret)
+	return results, nil
+}
+
+// BatchAuditAppeal 批量审核申诉，租约校验和批量审核评价一致
+func (uc *OperationUsecase) BatchAuditAppeal(ctx context.Context, param *BatchAuditAppealParam) ([]*BatchAuditResult, error) {
+	uc.log.WithContext(ctx).Infof("BatchAuditAppeal,param:%v", param)
+	results, claimed, err := uc.checkBatchTaskLease(ctx, TaskTypeAppeal, param.AppealIDs, param.OpUser)
+	if err != nil {
+		return nil, err
+	}
+	if len(claimed) == 0 {
+		return results, nil
+	}
+	p := *param
+	p.AppealIDs = claimed
+	ret, err := uc.repo.BatchAuditAppeal(ctx, &p)
+	if err != nil {
+		return nil, err
+	}
+	uc.mergeBatchResults(ctx, TaskTypeAppeal, param.OpUser, results, ret)
+	return results, nil
+}
+
+// checkBatchTaskLease 逐条校验任务租约，返回按请求顺序去重后的结果和运营持有租约的ID
+func (uc *OperationUsecase) checkBatchTaskLease(ctx context.Context, taskType int32, ids []int64, opUser string) ([]*BatchAuditResult, []int64, error) {
+	if len(ids) == 0 || len(ids) > maxBatchAuditSize {
+		return nil, nil, v1.ErrorInvalidParam("批量审核的数量必须在1到%d之间", maxBatchAuditSize)
+	}
+	seen := make(map[int64]struct{}, len(ids))
+	results := make([]*BatchAuditResult, 0, len(ids))
+	claimed := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		r := &BatchAuditResult{ID: id}
+		r.Err = uc.checkTaskLease(ctx, taskType, id, opUser)
+		if r.Err == nil {
+			claimed = append(claimed, id)
+		}
+		results = append(results, r)
+	}
+	return results, claimed, nil
+}
+
+// mergeBatchResults 把review-service返回的逐条结果合并到结果中，审核成功的任务释放租约
+func (uc *OperationUsecase) mergeBatchResults(ctx context.Context, taskType int32, opUser string, results, ret []*BatchAuditResult) {
+	m := make(map[int64]*BatchAuditResult, len(ret))
+	for _, v := range ret {
+		m[v.ID] = v
+	}
+	done := make([]*Task, 0, len(ret))
+	for _, r := range results {
+		if r.Err != nil {
+			continue
+		}
+		v, ok := m[r.ID]
+		if !ok {
+			r.Err = v1.ErrorInvalidParam("任务:%d没有审核结果", r.ID)
+			continue
+		}
+		r.Err = v.Err
+		if r.Err == nil {
+			done = append(done, &Task{TaskType: taskType, TaskID: r.ID})
+		}
+	}
+	uc.releaseTasks(ctx, opUser, done)
+}
@@ -3,6 +3,8 @@ package biz
 import (
 	"context"
 	"github.com/go-kratos/kratos/v2/log"
+	"review-o/internal/conf"
+	"time"
 )
 
 // AuditReviewParam 审核评价的参数
@@ -12,6 +14,7 @@ type AuditReviewParam struct {
 	OpReason  string
 	OpRemarks string
 	OpUser    string
+	Version   *int32 // 期望的评价版本号（乐观锁），为空时不校验
 }
 
 // AuditAppealParam 审核申诉的参数
@@ -23,27 +26,95 @@ type AuditAppealParam struct {
 	OpReason  string
 	OpRemarks string
 	OpUser    string
+	Version   *int32 // 期望的申诉版本号（乐观锁），为空时不校验
+}
+
+// AuditFollowUpParam 审核追评的参数
+type AuditFollowUpParam struct {
+	FollowUpID int64
+	Status     int
+	OpReason   string
+	OpRemarks  string
+	OpUser     string
+	Version    *int32 // 期望的追评版本号（乐观锁），为空时不校验
+}
+
+// ForceDeleteReviewParam 强制删除评价的参数
+type ForceDeleteReviewParam struct {
+	ReviewID int64
+	OpReason string
+	OpUser   string
+	Version  *int32 // 期望的评价版本号（乐观锁），为空时不校验
 }
 
 type OperationRepo interface {
 	AuditReview(context.Context, *AuditReviewParam) error
 	AuditAppeal(context.Context, *AuditAppealParam) error
+	AuditFollowUp(context.Context, *AuditFollowUpParam) error
+	ForceDeleteReview(context.Context, *ForceDeleteReviewParam) error
+	ListReviewRevisions(context.Context, int64) ([]*ReviewRevision, error)
+	ListEscalatedAppeals(ctx context.Context, page, size int32) ([]*AppealInfo, error)
+	GetReviewTimeline(context.Context, int64) ([]*OperationLog, error)
+	ListPendingReviews(context.Context, *ListPendingReviewParam) ([]*ReviewInfo, error)
+	ListPendingAppeals(context.Context, *ListPendingAppealParam) ([]*AppealInfo, error)
+	AcquireTaskLease(ctx context.Context, taskType int32, taskID int64, opUser string, ttl time.Duration) (bool, error)
+	RenewTaskLease(ctx context.Context, taskType int32, taskID int64, opUser string, ttl time.Duration) (bool, error)
+	ReleaseTaskLease(ctx context.Context, taskType int32, taskID int64, opUser string) (bool, error)
+	GetTaskLeaseHolder(ctx context.Context, taskType int32, taskID int64) (string, error)
+	BatchAuditReview(context.Context, *BatchAuditReviewParam) ([]*BatchAuditResult, error)
+	BatchAuditAppeal(context.Context, *BatchAuditAppealParam) ([]*BatchAuditResult, error)
 }
 
 type OperationUsecase struct {
 	repo OperationRepo
 	log  *log.Helper
+
+	leaseTTL time.Duration // 领取审核任务的租约时长
+	maxClaim int           // 一次最多领取的任务数
 }
 
-func NewOperationUsecase(repo OperationRepo, logger log.Logger) *OperationUsecase {
-	return &OperationUsecase{repo: repo, log: log.NewHelper(logger)}
+func NewOperationUsecase(repo OperationRepo, cfg *conf.Task, logger log.Logger) *OperationUsecase {
+	return &OperationUsecase{
+		repo: repo,
+		log:  log.NewHelper(logger),
+
+		leaseTTL: taskLeaseTTL(cfg),
+		maxClaim: taskMaxClaim(cfg),
+	}
 }
 
+// AuditReview 审核评价，运营必须先领取该评价的审核任务，审核完成后释放任务
 func (uc *OperationUsecase) AuditReview(ctx context.Context, param *AuditReviewParam) error {
 	uc.log.WithContext(ctx).Infof("AuditReview，param:%v", param)
-	return uc.repo.AuditReview(ctx, param)
+	if err := uc.checkTaskLease(ctx, TaskTypeReview, param.ReviewID, param.OpUser); err != nil {
+		return err
+	}
+	if err := uc.repo.AuditReview(ctx, param); err != nil {
+		return err
+	}
+	uc.releaseTasks(ctx, param.OpUser, []*Task{{TaskType: TaskTypeReview, TaskID: param.ReviewID}})
+	return nil
 }
+
+// AuditAppeal 审核申诉，运营必须先领取该申诉的审核任务，审核完成后释放任务
 func (uc *OperationUsecase) AuditAppeal(ctx context.Context, param *AuditAppealParam) error {
 	uc.log.WithContext(ctx).Infof("AuditAppeal,param:%v", param)
-	return uc.repo.AuditAppeal(ctx, param)
+	if err := uc.checkTaskLease(ctx, TaskTypeAppeal, param.AppealID, param.OpUser); err != nil {
+		return err
+	}
+	if err := uc.repo.AuditAppeal(ctx, param); err != nil {
+		return err
+	}
+	uc.releaseTasks(ctx, param.OpUser, []*Task{{TaskType: TaskTypeAppeal, TaskID: param.AppealID}})
+	return nil
+}
+
+func (uc *OperationUsecase) AuditFollowUp(ctx context.Context, param *AuditFollowUpParam) error {
+	uc.log.WithContext(ctx).Infof("AuditFollowUp,param:%v", param)
+	return uc.repo.AuditFollowUp(ctx, param)
+}
+
+func (uc *OperationUsecase) ForceDeleteReview(ctx context.Context, param *ForceDeleteReviewParam) error {
+	uc.log.WithContext(ctx).Infof("ForceDeleteReview,param:%v", param)
+	return uc.repo.ForceDeleteReview(ctx, param)
 }
@@ -0,0 +1,95 @@
+package biz
+
+import (
+	"context"
+)
+
+// 内容差异片段的类型
+const (
+	DiffEqual  = 0 // 未变化
+	DiffInsert = 1 // 新增
+	DiffDelete = 2 // 删除
+)
+
+// DiffSegment 内容差异片段
+type DiffSegment struct {
+	Op   int
+	Text string
+}
+
+// ReviewRevision 评价的一个版本
+type ReviewRevision struct {
+	Revision     int32
+	Content      string
+	Score        int32
+	ServiceScore int32
+	ExpressScore int32
+	PicInfo      string
+	VideoInfo    string
+	Status       int32
+	CreateAt     string
+	Diff         []*DiffSegment // 和上一个版本相比内容的差异，第一个版本为空
+}
+
+// ListReviewRevisions 查询评价的修改历史，并计算每个版本相对上一个版本的内容差异
+func (uc *OperationUsecase) ListReviewRevisions(ctx context.Context, reviewID int64) ([]*ReviewRevision, error) {
+	uc.log.WithContext(ctx).Infof("ListReviewRevisions,reviewID:%v", reviewID)
+	list, err := uc.repo.ListReviewRevisions(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	for i := 1; i < len(list); i++ {
+		list[i].Diff = diffText(list[i-1].Content, list[i].Content)
+	}
+	return list, nil
+}
+
+// diffText 按字符计算两段文本的差异（最长公共子序列）
+// 评价内容最长512个字符，O(n*m)的计算量可以接受
+func diffText(from, to string) []*DiffSegment {
+	a, b := []rune(from), []rune(to)
+	// lcs[i][j] 表示 a[i:] 和 b[j:] 的最长公共子序列长度
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var segments []*DiffSegment
+	appendRune := func(op int, r rune) {
+		if n := len(segments); n > 0 && segments[n-1].Op == op {
+			segments[n-1].Text += string(r)
+			return
+		}
+		segments = append(segments, &DiffSegment{Op: op, Text: string(r)})
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			appendRune(DiffEqual, a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			appendRune(DiffDelete, a[i])
+			i++
+		default:
+			appendRune(DiffInsert, b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		appendRune(DiffDelete, a[i])
+	}
+	for ; j < len(b); j++ {
+		appendRune(DiffInsert, b[j])
+	}
+	return segments
+}
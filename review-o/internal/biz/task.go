@@ -0,0 +1,256 @@
+package biz
+
+import (
+	"context"
+	v1 "review-o/api/operation/v1"
+	"review-o/internal/conf"
+	"time"
+)
+
+// 审核任务类型
+const (
+	TaskTypeReview int32 = 1 // 评价审核
+	TaskTypeAppeal int32 = 2 // 申诉审核
+)
+
+const (
+	defaultTaskLeaseTTL = 10 * time.Minute // 没有配置时领取任务的租约时长
+	defaultMaxClaim     = 20               // 没有配置时一次最多领取的任务数
+
+	claimPageSize = 50 // 领取任务时每次从待审核队列中取的数量
+	claimMaxPages = 5  // 队列前面的任务都被其他运营领取时，最多往后翻的页数
+)
+
+// ReviewInfo 待审核的评价
+type ReviewInfo struct {
+	ReviewID     int64
+	UserID       int64
+	OrderID      int64
+	StoreID      int64
+	SkuID        int64
+	Score        int32
+	ServiceScore int32
+	ExpressScore int32
+	Content      string
+	PicInfo      string
+	VideoInfo    string
+	HasMedia     int32
+	Status       int32
+	OpReason     string // 机审结果
+	Version      int32
+	CreateAt     string
+}
+
+// ListPendingReviewParam 待审核评价队列的筛选条件，零值表示不过滤
+type ListPendingReviewParam struct {
+	StoreID   int64
+	Score     int32
+	HasMedia  *int32
+	StartTime string // 评价创建时间范围，格式：2006-01-02 15:04:05
+	EndTime   string
+	Page      int32
+	Size      int32
+}
+
+// ListPendingAppealParam 待审核申诉队列的筛选条件，零值表示不过滤
+type ListPendingAppealParam struct {
+	StoreID   int64
+	Reason    string
+	SLAStatus int32 // 1未超时；2已超时；3已升级
+	Page      int32
+	Size      int32
+}
+
+// ClaimTasksParam 领取审核任务的参数，按任务类型使用对应队列的筛选条件
+type ClaimTasksParam struct {
+	OpUser   string
+	TaskType int32
+	Count    int32
+	Review   ListPendingReviewParam
+	Appeal   ListPendingAppealParam
+}
+
+// Task 领取到的审核任务
+type Task struct {
+	TaskType int32
+	TaskID   int64 // 评价审核为评价ID，申诉审核为申诉ID
+	ExpireAt time.Time
+	Review   *ReviewInfo
+	Appeal   *AppealInfo
+}
+
+func taskLeaseTTL(cfg *conf.Task) time.Duration {
+	if d := cfg.GetLeaseTtl().AsDuration(); d > 0 {
+		return d
+	}
+	return defaultTaskLeaseTTL
+}
+
+func taskMaxClaim(cfg *conf.Task) int {
+	if n := cfg.GetMaxClaim(); n > 0 {
+		return int(n)
+	}
+	return defaultMaxClaim
+}
+
+// ListPendingReviews 待审核评价队列
+func (uc *OperationUsecase) ListPendingReviews(ctx context.Context, param *ListPendingReviewParam) ([]*ReviewInfo, error) {
+	uc.log.WithContext(ctx).Infof("ListPendingReviews,param:%v", param)
+	return uc.repo.ListPendingReviews(ctx, param)
+}
+
+// ListPendingAppeals 待审核申诉队列
+func (uc *OperationUsecase) ListPendingAppeals(ctx context.Context, param *ListPendingAppealParam) ([]*AppealInfo, error) {
+	uc.log.WithContext(ctx).Infof("ListPendingAppeals,param:%v", param)
+	return uc.repo.ListPendingAppeals(ctx, param)
+}
+
+// ClaimTasks 从待审核队列中领取最多Count个任务
+// 每个任务在redis中加一个带过期时间的租约，已被其他运营领取的任务跳过，避免两个运营审核同一条评价
+// 运营重复领取时，自己已经领取的任务会再次返回并续期
+func (uc *OperationUsecase) ClaimTasks(ctx context.Context, param *ClaimTasksParam) ([]*Task, error) {
+	uc.log.WithContext(ctx).Infof("ClaimTasks,param:%v", param)
+	if len(param.OpUser) == 0 {
+		return nil, v1.ErrorInvalidParam("运营者标识不能为空")
+	}
+	count := int(param.Count)
+	if count <= 0 || count > uc.maxClaim {
+		count = uc.maxClaim
+	}
+	var listTasks func(page int32) ([]*Task, error)
+	switch param.TaskType {
+	case TaskTypeReview:
+		listTasks = func(page int32) ([]*Task, error) {
+			p := param.Review
+			p.Page, p.Size = page, claimPageSize
+			list, err := uc.repo.ListPendingReviews(ctx, &p)
+			if err != nil {
+				return nil, err
+			}
+			tasks := make([]*Task, 0, len(list))
+			for _, v := range list {
+				tasks = append(tasks, &Task{TaskType: TaskTypeReview, TaskID: v.ReviewID, Review: v})
+			}
+			return tasks, nil
+		}
+	case TaskTypeAppeal:
+		listTasks = func(page int32) ([]*Task, error) {
+			p := param.Appeal
+			p.Page, p.Size = page, claimPageSize
+			list, err := uc.repo.ListPendingAppeals(ctx, &p)
+			if err != nil {
+				return nil, err
+			}
+			tasks := make([]*Task, 0, len(list))
+			for _, v := range list {
+				tasks = append(tasks, &Task{TaskType: TaskTypeAppeal, TaskID: v.AppealID, Appeal: v})
+			}
+			return tasks, nil
+		}
+	default:
+		return nil, v1.ErrorInvalidParam("不支持的任务类型:%d", param.TaskType)
+	}
+
+	expireAt := time.Now().Add(uc.leaseTTL)
+	claimed := make([]*Task, 0, count)
+	for page := int32(1); page <= claimMaxPages && len(claimed) < count; page++ {
+		list, err := listTasks(page)
+		if err != nil {
+			uc.releaseTasks(ctx, param.OpUser, claimed)
+			return nil, err
+		}
+		for _, t := range list {
+			if len(claimed) >= count {
+				break
+			}
+			ok, err := uc.repo.AcquireTaskLease(ctx, t.TaskType, t.TaskID, param.OpUser, uc.leaseTTL)
+			if err != nil {
+				uc.releaseTasks(ctx, param.OpUser, claimed)
+				return nil, err
+			}
+			if !ok {
+				continue // 已被其他运营领取
+			}
+			t.ExpireAt = expireAt
+			claimed = append(claimed, t)
+		}
+		if len(list) < claimPageSize {
+			break
+		}
+	}
+	return claimed, nil
+}
+
+// RenewTasks 给运营自己持有的任务续期，返回续期成功的任务ID，租约已过期或已被别人领取的不返回
+func (uc *OperationUsecase) RenewTasks(ctx context.Context, opUser string, taskType int32, taskIDs []int64) ([]int64, time.Time, error) {
+	uc.log.WithContext(ctx).Infof("RenewTasks,opUser:%v,taskType:%v,taskIDs:%v", opUser, taskType, taskIDs)
+	if err := uc.checkTaskIDs(opUser, taskType, taskIDs); err != nil {
+		return nil, time.Time{}, err
+	}
+	expireAt := time.Now().Add(uc.leaseTTL)
+	renewed := make([]int64, 0, len(taskIDs))
+	for _, id := range taskIDs {
+		ok, err := uc.repo.RenewTaskLease(ctx, taskType, id, opUser, uc.leaseTTL)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		if ok {
+			renewed = append(renewed, id)
+		}
+	}
+	return renewed, expireAt, nil
+}
+
+// ReleaseTasks 运营放弃自己持有的任务，任务回到待审核队列中，返回释放成功的任务ID
+func (uc *OperationUsecase) ReleaseTasks(ctx context.Context, opUser string, taskType int32, taskIDs []int64) ([]int64, error) {
+	uc.log.WithContext(ctx).Infof("ReleaseTasks,opUser:%v,taskType:%v,taskIDs:%v", opUser, taskType, taskIDs)
+	if err := uc.checkTaskIDs(opUser, taskType, taskIDs); err != nil {
+		return nil, err
+	}
+	released := make([]int64, 0, len(taskIDs))
+	for _, id := range taskIDs {
+		ok, err := uc.repo.ReleaseTaskLease(ctx, taskType, id, opUser)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			released = append(released, id)
+		}
+	}
+	return released, nil
+}
+
+func (uc *OperationUsecase) checkTaskIDs(opUser string, taskType int32, taskIDs []int64) error {
+	if len(opUser) == 0 {
+		return v1.ErrorInvalidParam("运营者标识不能为空")
+	}
+	if taskType != TaskTypeReview && taskType != TaskTypeAppeal {
+		return v1.ErrorInvalidParam("不支持的任务类型:%d", taskType)
+	}
+	if len(taskIDs) == 0 || len(taskIDs) > uc.maxClaim {
+		return v1.ErrorInvalidParam("任务数量必须在1到%d之间", uc.maxClaim)
+	}
+	return nil
+}
+
+// checkTaskLease 审核前校验运营持有任务的租约
+// 校验和审核之间租约可能刚好过期被别人领取，此时由review-service的乐观锁兜底
+func (uc *OperationUsecase) checkTaskLease(ctx context.Context, taskType int32, taskID int64, opUser string) error {
+	holder, err := uc.repo.GetTaskLeaseHolder(ctx, taskType, taskID)
+	if err != nil {
+		return err
+	}
+	if len(opUser) == 0 || holder != opUser {
+		return v1.ErrorTaskNotClaimed("任务:%d未领取或租约已过期，请先领取", taskID)
+	}
+	return nil
+}
+
+// releaseTasks 尽力释放租约，失败时等租约自动过期
+func (uc *OperationUsecase) releaseTasks(ctx context.Context, opUser string, tasks []*Task) {
+	for _, t := range tasks {
+		if _, err := uc.repo.ReleaseTaskLease(ctx, t.TaskType, t.TaskID, opUser); err != nil {
+			uc.log.WithContext(ctx).Warnf("release task:%d-%d fail, err:%v", t.TaskType, t.TaskID, err)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package biz
+
+import (
+	"context"
+)
+
+// OperationLog 评价的一条操作记录
+type OperationLog struct {
+	TargetType   int32 // 1评价；2回复；3申诉；4追评
+	TargetID     int64
+	Action       int32 // 1创建；2审核；3修改；4删除；5撤回；6超时升级；7超时关闭；8隐藏
+	Actor        string
+	Role         int32 // 1用户；2商家；3运营；4系统
+	BeforeStatus int32
+	AfterStatus  int32
+	Reason       string
+	Remarks      string
+	TraceID      string
+	MetaJSON     string
+	CreateAt     string
+}
+
+// GetReviewTimeline 查询评价及其回复、申诉、追评的完整操作记录
+func (uc *OperationUsecase) GetReviewTimeline(ctx context.Context, reviewID int64) ([]*OperationLog, error) {
+	uc.log.WithContext(ctx).Infof("GetReviewTimeline,reviewID:%v", reviewID)
+	return uc.repo.GetReviewTimeline(ctx, reviewID)
+}
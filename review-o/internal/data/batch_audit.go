@@ -0,0 +1,53 @@
+package data
+
+import (
+	"context"
+
+	reviewv1 "review-o/api/review/v1"
+	"review-o/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+func (r *operationRepo) BatchAuditReview(ctx context.Context, param *biz.BatchAuditReviewParam) ([]*biz.BatchAuditResult, error) {
+	r.log.WithContext(ctx).Infof("BatchAuditReview, param:%v", param)
+	ret, err := r.data.rc.BatchAuditReview(ctx, &reviewv1.BatchAuditReviewRequest{
+		ReviewIDs: param.ReviewIDs,
+		Status:    int32(param.Status),
+		OpUser:    param.OpUser,
+		OpReason:  param.OpReason,
+		OpRemarks: &param.OpRemarks,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toBatchAuditResults(ret.GetList()), nil
+}
+
+func (r *operationRepo) BatchAuditAppeal(ctx context.Context, param *biz.BatchAuditAppealParam) ([]*biz.BatchAuditResult, error) {
+	r.log.WithContext(ctx).Infof("BatchAuditAppeal, param:%v", param)
+	ret, err := r.data.rc.BatchAuditAppeal(ctx, &reviewv1.BatchAuditAppealRequest{
+		AppealIDs: param.AppealIDs,
+		Status:    int32(param.Status),
+		OpUser:    param.OpUser,
+		OpReason:  param.OpReason,
+		OpRemarks: &param.OpRemarks,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toBatchAuditResults(ret.GetList()), nil
+}
+
+// toBatchAuditResults 把review-service返回的单条失败原因还原成kratos错误
+func toBatchAuditResults(list []*reviewv1.BatchAuditItem) []*biz.BatchAuditResult {
+	results := make([]*biz.BatchAuditResult, 0, len(list))
+	for _, v := range list {
+		r := &biz.BatchAuditResult{ID: v.GetID()}
+		if !v.GetSuccess() {
+			r.Err = errors.New(int(v.GetCode()), v.GetReason(), v.GetMessage())
+		}
+		results = append(results, r)
+	}
+	return results
+}
@@ -12,28 +12,41 @@ import (
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/wire"
 	consulAPI "github.com/hashicorp/consul/api"
+	"github.com/redis/go-redis/v9"
 )
 
 // ProviderSet is data providers.
-var ProviderSet = wire.NewSet(NewData, NewDiscovery, NewReviewServiceClient, NewOperationRepo)
+var ProviderSet = wire.NewSet(NewData, NewDiscovery, NewReviewServiceClient, NewRedisClient, NewOperationRepo)
 
 // Data .
 type Data struct {
 	rc  v1.ReviewClient
+	rdb *redis.Client
 	log *log.Helper
 }
 
 // NewData .
-func NewData(c *conf.Data, rc v1.ReviewClient, logger log.Logger) (*Data, func(), error) {
+func NewData(c *conf.Data, rc v1.ReviewClient, rdb *redis.Client, logger log.Logger) (*Data, func(), error) {
 	cleanup := func() {
 		log.NewHelper(logger).Info("closing the data resources")
+		_ = rdb.Close()
 	}
 	return &Data{
 		rc:  rc,
+		rdb: rdb,
 		log: log.NewHelper(logger),
 	}, cleanup, nil
 }
 
+// NewRedisClient 审核任务的领取租约保存在redis中
+func NewRedisClient(cfg *conf.Data) *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr:         cfg.Redis.Addr,
+		ReadTimeout:  cfg.Redis.ReadTimeout.AsDuration(),
+		WriteTimeout: cfg.Redis.WriteTimeout.AsDuration(),
+	})
+}
+
 func NewDiscovery(conf *conf.Registry) registry.Discovery {
 	c := consulAPI.DefaultConfig()
 	c.Address = conf.Consul.Address
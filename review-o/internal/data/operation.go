@@ -30,6 +30,7 @@ func (r *operationRepo) AuditReview(ctx context.Context, param *biz.AuditReviewP
 		OpUser:    param.OpUser,
 		OpReason:  param.OpReason,
 		OpRemarks: &param.OpRemarks,
+		Version:   param.Version,
 	})
 	r.log.WithContext(ctx).Debugf("AuditReview reply ret: %v, err:%v", ret, err)
 	return err
@@ -40,10 +41,122 @@ func (r *operationRepo) AuditAppeal(ctx context.Context, param *biz.AuditAppealP
 	ret, err := r.data.rc.AuditAppeal(ctx, &reviewv1.AuditAppealRequest{
 		AppealID:  param.AppealID,
 		ReviewID:  param.ReviewID,
+		StoreID:   param.StoreID,
 		Status:    int32(param.Status),
 		OpUser:    param.OpUser,
+		OpReason:  param.OpReason,
 		OpRemarks: &param.OpRemarks,
+		Version:   param.Version,
 	})
 	r.log.WithContext(ctx).Debugf("AuditReview reply ret: %v, err:%v", ret, err)
 	return err
 }
+
+func (r *operationRepo) AuditFollowUp(ctx context.Context, param *biz.AuditFollowUpParam) error {
+	r.log.WithContext(ctx).Infof("AuditFollowUp, param:%v", param)
+	ret, err := r.data.rc.AuditFollowUp(ctx, &reviewv1.AuditFollowUpRequest{
+		FollowUpID: param.FollowUpID,
+		Status:     int32(param.Status),
+		OpUser:     param.OpUser,
+		OpReason:   param.OpReason,
+		OpRemarks:  &param.OpRemarks,
+		Version:    param.Version,
+	})
+	r.log.WithContext(ctx).Debugf("AuditFollowUp reply ret: %v, err:%v", ret, err)
+	return err
+}
+
+func (r *operationRepo) ForceDeleteReview(ctx context.Context, param *biz.ForceDeleteReviewParam) error {
+	r.log.WithContext(ctx).Infof("ForceDeleteReview, param:%v", param)
+	ret, err := r.data.rc.ForceDeleteReview(ctx, &reviewv1.ForceDeleteReviewRequest{
+		ReviewID: param.ReviewID,
+		OpUser:   param.OpUser,
+		OpReason: param.OpReason,
+		Version:  param.Version,
+	})
+	r.log.WithContext(ctx).Debugf("ForceDeleteReview reply ret: %v, err:%v", ret, err)
+	return err
+}
+
+func (r *operationRepo) ListReviewRevisions(ctx context.Context, reviewID int64) ([]*biz.ReviewRevision, error) {
+	r.log.WithContext(ctx).Infof("ListReviewRevisions, reviewID:%v", reviewID)
+	ret, err := r.data.rc.ListReviewRevisions(ctx, &reviewv1.ListReviewRevisionsRequest{ReviewID: reviewID})
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*biz.ReviewRevision, 0, len(ret.GetList()))
+	for _, v := range ret.GetList() {
+		list = append(list, &biz.ReviewRevision{
+			Revision:     v.GetRevision(),
+			Content:      v.GetContent(),
+			Score:        v.GetScore(),
+			ServiceScore: v.GetServiceScore(),
+			ExpressScore: v.GetExpressScore(),
+			PicInfo:      v.GetPicInfo(),
+			VideoInfo:    v.GetVideoInfo(),
+			Status:       v.GetStatus(),
+			CreateAt:     v.GetCreateAt(),
+		})
+	}
+	return list, nil
+}
+
+func (r *operationRepo) ListEscalatedAppeals(ctx context.Context, page, size int32) ([]*biz.AppealInfo, error) {
+	r.log.WithContext(ctx).Infof("ListEscalatedAppeals, page:%v, size:%v", page, size)
+	ret, err := r.data.rc.ListEscalatedAppeals(ctx, &reviewv1.ListEscalatedAppealsRequest{Page: page, Size: size})
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*biz.AppealInfo, 0, len(ret.GetList()))
+	for _, v := range ret.GetList() {
+		list = append(list, toAppealInfo(v))
+	}
+	return list, nil
+}
+
+func toAppealInfo(v *reviewv1.AppealInfo) *biz.AppealInfo {
+	return &biz.AppealInfo{
+		AppealID:    v.GetAppealID(),
+		ReviewID:    v.GetReviewID(),
+		StoreID:     v.GetStoreID(),
+		Status:      v.GetStatus(),
+		Reason:      v.GetReason(),
+		Content:     v.GetContent(),
+		PicInfo:     v.GetPicInfo(),
+		VideoInfo:   v.GetVideoInfo(),
+		SubmitCount: v.GetSubmitCount(),
+		OpReason:    v.GetOpReason(),
+		OpRemarks:   v.GetOpRemarks(),
+		Version:     v.GetVersion(),
+		DeadlineAt:  v.GetDeadlineAt(),
+		SLAStatus:   v.GetSlaStatus(),
+		CreateAt:    v.GetCreateAt(),
+		UpdateAt:    v.GetUpdateAt(),
+	}
+}
+
+func (r *operationRepo) GetReviewTimeline(ctx context.Context, reviewID int64) ([]*biz.OperationLog, error) {
+	r.log.WithContext(ctx).Infof("GetReviewTimeline, reviewID:%v", reviewID)
+	ret, err := r.data.rc.GetReviewTimeline(ctx, &reviewv1.GetReviewTimelineRequest{ReviewID: reviewID})
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*biz.OperationLog, 0, len(ret.GetList()))
+	for _, v := range ret.GetList() {
+		list = append(list, &biz.OperationLog{
+			TargetType:   v.GetTargetType(),
+			TargetID:     v.GetTargetID(),
+			Action:       v.GetAction(),
+			Actor:        v.GetActor(),
+			Role:         v.GetRole(),
+			BeforeStatus: v.GetBeforeStatus(),
+			AfterStatus:  v.GetAfterStatus(),
+			Reason:       v.GetReason(),
+			Remarks:      v.GetRemarks(),
+			TraceID:      v.GetTraceID(),
+			MetaJSON:     v.GetMetaJSON(),
+			CreateAt:     v.GetCreateAt(),
+		})
+	}
+	return list, nil
+}
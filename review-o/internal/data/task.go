@@ -0,0 +1,120 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	reviewv1 "review-o/api/review/v1"
+	"review-o/internal/biz"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// acquireLeaseScript 任务没有租约或者租约属于自己时加锁并刷新过期时间
+var acquireLeaseScript = redis.NewScript(`
+local holder = redis.call("get", KEYS[1])
+if holder == false or holder == ARGV[1] then
+	redis.call("set", KEYS[1], ARGV[1], "px", ARGV[2])
+	return 1
+end
+return 0
+`)
+
+// renewLeaseScript 只给自己持有的租约续期
+var renewLeaseScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("pexpire", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// releaseLeaseScript 只释放自己持有的租约，避免租约过期后误删别人的租约
+var releaseLeaseScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0
+`)
+
+func taskLeaseKey(taskType int32, taskID int64) string {
+	return fmt.Sprintf("review-o:task:lease:%d:%d", taskType, taskID)
+}
+
+func (r *operationRepo) AcquireTaskLease(ctx context.Context, taskType int32, taskID int64, opUser string, ttl time.Duration) (bool, error) {
+	return acquireLeaseScript.Run(ctx, r.data.rdb, []string{taskLeaseKey(taskType, taskID)}, opUser, ttl.Milliseconds()).Bool()
+}
+
+func (r *operationRepo) RenewTaskLease(ctx context.Context, taskType int32, taskID int64, opUser string, ttl time.Duration) (bool, error) {
+	return renewLeaseScript.Run(ctx, r.data.rdb, []string{taskLeaseKey(taskType, taskID)}, opUser, ttl.Milliseconds()).Bool()
+}
+
+func (r *operationRepo) ReleaseTaskLease(ctx context.Context, taskType int32, taskID int64, opUser string) (bool, error) {
+	return releaseLeaseScript.Run(ctx, r.data.rdb, []string{taskLeaseKey(taskType, taskID)}, opUser).Bool()
+}
+
+// GetTaskLeaseHolder 查询任务租约的持有人，没有被领取时返回空字符串
+func (r *operationRepo) GetTaskLeaseHolder(ctx context.Context, taskType int32, taskID int64) (string, error) {
+	holder, err := r.data.rdb.Get(ctx, taskLeaseKey(taskType, taskID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return holder, err
+}
+
+func (r *operationRepo) ListPendingReviews(ctx context.Context, param *biz.ListPendingReviewParam) ([]*biz.ReviewInfo, error) {
+	r.log.WithContext(ctx).Infof("ListPendingReviews, param:%v", param)
+	ret, err := r.data.rc.ListPendingReviews(ctx, &reviewv1.ListPendingReviewsRequest{
+		StoreID:   param.StoreID,
+		Score:     param.Score,
+		HasMedia:  param.HasMedia,
+		StartTime: param.StartTime,
+		EndTime:   param.EndTime,
+		Page:      param.Page,
+		Size:      param.Size,
+	})
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*biz.ReviewInfo, 0, len(ret.GetList()))
+	for _, v := range ret.GetList() {
+		list = append(list, &biz.ReviewInfo{
+			ReviewID:     v.GetReviewID(),
+			UserID:       v.GetUserID(),
+			OrderID:      v.GetOrderID(),
+			StoreID:      v.GetStoreID(),
+			SkuID:        v.GetSkuID(),
+			Score:        v.GetScore(),
+			ServiceScore: v.GetServiceScore(),
+			ExpressScore: v.GetExpressScore(),
+			Content:      v.GetContent(),
+			PicInfo:      v.GetPicInfo(),
+			VideoInfo:    v.GetVideoInfo(),
+			HasMedia:     v.GetHasMedia(),
+			Status:       v.GetStatus(),
+			OpReason:     v.GetOpReason(),
+			Version:      v.GetVersion(),
+			CreateAt:     v.GetCreateAt(),
+		})
+	}
+	return list, nil
+}
+
+func (r *operationRepo) ListPendingAppeals(ctx context.Context, param *biz.ListPendingAppealParam) ([]*biz.AppealInfo, error) {
+	r.log.WithContext(ctx).Infof("ListPendingAppeals, param:%v", param)
+	ret, err := r.data.rc.ListPendingAppeals(ctx, &reviewv1.ListPendingAppealsRequest{
+		StoreID:   param.StoreID,
+		Reason:    param.Reason,
+		SlaStatus: param.SLAStatus,
+		Page:      param.Page,
+		Size:      param.Size,
+	})
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*biz.AppealInfo, 0, len(ret.GetList()))
+	for _, v := range ret.GetList() {
+		list = append(list, toAppealInfo(v))
+	}
+	return list, nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+
+	pb "review-o/api/operation/v1"
+	"review-o/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+func (s *OperationService) BatchAuditReview(ctx context.Context, req *pb.BatchAuditReviewRequest) (*pb.BatchAuditReviewReply, error) {
+	ret, err := s.uc.BatchAuditReview(ctx, &biz.BatchAuditReviewParam{
+		ReviewIDs: req.GetReviewIDs(),
+		Status:    int(req.GetStatus()),
+		OpReason:  req.GetOpReason(),
+		OpRemarks: req.GetOpRemarks(),
+		OpUser:    req.GetOpUser(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BatchAuditReviewReply{List: toBatchAuditItems(ret)}, nil
+}
+
+func (s *OperationService) BatchAuditAppeal(ctx context.Context, req *pb.BatchAuditAppealRequest) (*pb.BatchAuditAppealReply, error) {
+	ret, err := s.uc.BatchAuditAppeal(ctx, &biz.BatchAuditAppealParam{
+		AppealIDs: req.GetAppealIDs(),
+		Status:    int(req.GetStatus()),
+		OpReason:  req.GetOpReason(),
+		OpRemarks: req.GetOpRemarks(),
+		OpUser:    req.GetOpUser(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BatchAuditAppealReply{List: toBatchAuditItems(ret)}, nil
+}
+
+func toBatchAuditItems(results []*biz.BatchAuditResult) []*pb.BatchAuditItem {
+	list := make([]*pb.BatchAuditItem, 0, len(results))
+	for _, v := range results {
+		item := &pb.BatchAuditItem{ID: v.ID, Success: v.Err == nil}
+		if v.Err != nil {
+			e := errors.FromError(v.Err)
+			item.Code = e.Code
+			item.Reason = e.Reason
+			item.Message = e.Message
+		}
+		list = append(list, item)
+	}
+	return list
+}
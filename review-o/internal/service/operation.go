@@ -26,6 +26,7 @@ func (s *OperationService) AuditReview(ctx context.Context, req *pb.AuditReviewR
 		OpReason:  req.GetOpReason(),
 		OpRemarks: req.GetOpRemarks(),
 		OpUser:    req.GetOpUser(),
+		Version:   req.Version,
 	})
 	return &pb.AuditReviewReply{}, err
 }
@@ -33,10 +34,119 @@ func (s *OperationService) AuditAppeal(ctx context.Context, req *pb.AuditAppealR
 	err := s.uc.AuditAppeal(ctx, &biz.AuditAppealParam{
 		AppealID:  req.GetAppealID(),
 		ReviewID:  req.GetReviewID(),
+		StoreID:   req.GetStoreID(),
 		Status:    int(req.GetStatus()),
 		OpReason:  req.GetOpReason(),
 		OpRemarks: req.GetOpRemarks(),
 		OpUser:    req.GetOpUser(),
+		Version:   req.Version,
 	})
 	return &pb.AuditAppealReply{}, err
 }
+
+func (s *OperationService) AuditFollowUp(ctx context.Context, req *pb.AuditFollowUpRequest) (*pb.AuditFollowUpReply, error) {
+	err := s.uc.AuditFollowUp(ctx, &biz.AuditFollowUpParam{
+		FollowUpID: req.GetFollowUpID(),
+		Status:     int(req.GetStatus()),
+		OpReason:   req.GetOpReason(),
+		OpRemarks:  req.GetOpRemarks(),
+		OpUser:     req.GetOpUser(),
+		Version:    req.Version,
+	})
+	return &pb.AuditFollowUpReply{}, err
+}
+
+func (s *OperationService) ForceDeleteReview(ctx context.Context, req *pb.ForceDeleteReviewRequest) (*pb.ForceDeleteReviewReply, error) {
+	err := s.uc.ForceDeleteReview(ctx, &biz.ForceDeleteReviewParam{
+		ReviewID: req.GetReviewID(),
+		OpReason: req.GetOpReason(),
+		OpUser:   req.GetOpUser(),
+		Version:  req.Version,
+	})
+	return &pb.ForceDeleteReviewReply{}, err
+}
+
+func (s *OperationService) ListReviewRevisions(ctx context.Context, req *pb.ListReviewRevisionsRequest) (*pb.ListReviewRevisionsReply, error) {
+	ret, err := s.uc.ListReviewRevisions(ctx, req.GetReviewID())
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*pb.ReviewRevision, 0, len(ret))
+	for _, v := range ret {
+		diff := make([]*pb.DiffSegment, 0, len(v.Diff))
+		for _, d := range v.Diff {
+			diff = append(diff, &pb.DiffSegment{Op: int32(d.Op), Text: d.Text})
+		}
+		list = append(list, &pb.ReviewRevision{
+			Revision:     v.Revision,
+			Content:      v.Content,
+			Score:        v.Score,
+			ServiceScore: v.ServiceScore,
+			ExpressScore: v.ExpressScore,
+			PicInfo:      v.PicInfo,
+			VideoInfo:    v.VideoInfo,
+			Status:       v.Status,
+			CreateAt:     v.CreateAt,
+			Diff:         diff,
+		})
+	}
+	return &pb.ListReviewRevisionsReply{ReviewID: req.GetReviewID(), List: list}, nil
+}
+
+func (s *OperationService) ListEscalatedAppeals(ctx context.Context, req *pb.ListEscalatedAppealsRequest) (*pb.ListEscalatedAppealsReply, error) {
+	ret, err := s.uc.ListEscalatedAppeals(ctx, req.GetPage(), req.GetSize())
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*pb.AppealInfo, 0, len(ret))
+	for _, v := range ret {
+		list = append(list, toAppealInfo(v))
+	}
+	return &pb.ListEscalatedAppealsReply{List: list}, nil
+}
+
+func toAppealInfo(v *biz.AppealInfo) *pb.AppealInfo {
+	return &pb.AppealInfo{
+		AppealID:    v.AppealID,
+		ReviewID:    v.ReviewID,
+		StoreID:     v.StoreID,
+		Status:      v.Status,
+		Reason:      v.Reason,
+		Content:     v.Content,
+		PicInfo:     v.PicInfo,
+		VideoInfo:   v.VideoInfo,
+		SubmitCount: v.SubmitCount,
+		OpReason:    v.OpReason,
+		OpRemarks:   v.OpRemarks,
+		Version:     v.Version,
+		DeadlineAt:  v.DeadlineAt,
+		SlaStatus:   v.SLAStatus,
+		CreateAt:    v.CreateAt,
+		UpdateAt:    v.UpdateAt,
+	}
+}
+
+func (s *OperationService) GetReviewTimeline(ctx context.Context, req *pb.GetReviewTimelineRequest) (*pb.GetReviewTimelineReply, error) {
+	ret, err := s.uc.GetReviewTimeline(ctx, req.GetReviewID())
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*pb.OperationLog, 0, len(ret))
+	for _, v := range ret {
+		list = append(list, &pb.OperationLog{
+			TargetType:   v.TargetType,
+			TargetID:     v.TargetID,
+			Action:       v.Action,
+			Actor:        v.Actor,
+			Role:         v.Role,
+			BeforeStatus: v.BeforeStatus,
+			AfterStatus:  v.AfterStatus,
+			Reason:       v.Reason,
+			Remarks:      v.Remarks,
+			TraceID:      v.TraceID,
+			MetaJSON:     v.MetaJSON,
+			CreateAt:     v.CreateAt,
+		})
+	}
+	return &pb.GetReviewTimelineReply{ReviewID: req.GetReviewID(), List: list}, nil
+}
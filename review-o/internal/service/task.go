@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	pb "review-o/api/operation/v1"
+	"review-o/internal/biz"
+)
+
+func (s *OperationService) ListPendingReviews(ctx context.Context, req *pb.ListPendingReviewsRequest) (*pb.ListPendingReviewsReply, error) {
+	ret, err := s.uc.ListPendingReviews(ctx, &biz.ListPendingReviewParam{
+		StoreID:   req.GetStoreID(),
+		Score:     req.GetScore(),
+		HasMedia:  req.HasMedia,
+		StartTime: req.GetStartTime(),
+		EndTime:   req.GetEndTime(),
+		Page:      req.GetPage(),
+		Size:      req.GetSize(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*pb.ReviewInfo, 0, len(ret))
+	for _, v := range ret {
+		list = append(list, toReviewInfo(v))
+	}
+	return &pb.ListPendingReviewsReply{List: list}, nil
+}
+
+func (s *OperationService) ListPendingAppeals(ctx context.Context, req *pb.ListPendingAppealsRequest) (*pb.ListPendingAppealsReply, error) {
+	ret, err := s.uc.ListPendingAppeals(ctx, &biz.ListPendingAppealParam{
+		StoreID:   req.GetStoreID(),
+		Reason:    req.GetReason(),
+		SLAStatus: req.GetSlaStatus(),
+		Page:      req.GetPage(),
+		Size:      req.GetSize(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*pb.AppealInfo, 0, len(ret))
+	for _, v := range ret {
+		list = append(list, toAppealInfo(v))
+	}
+	return &pb.ListPendingAppealsReply{List: list}, nil
+}
+
+func (s *OperationService) ClaimTasks(ctx context.Context, req *pb.ClaimTasksRequest) (*pb.ClaimTasksReply, error) {
+	param := &biz.ClaimTasksParam{
+		OpUser:   req.GetOpUser(),
+		TaskType: req.GetTaskType(),
+		Count:    req.GetCount(),
+	}
+	if f := req.GetReviewFilter(); f != nil {
+		param.Review = biz.ListPendingReviewParam{
+			StoreID:   f.GetStoreID(),
+			Score:     f.GetScore(),
+			HasMedia:  f.HasMedia,
+			StartTime: f.GetStartTime(),
+			EndTime:   f.GetEndTime(),
+		}
+	}
+	if f := req.GetAppealFilter(); f != nil {
+		param.Appeal = biz.ListPendingAppealParam{
+			StoreID:   f.GetStoreID(),
+			Reason:    f.GetReason(),
+			SLAStatus: f.GetSlaStatus(),
+		}
+	}
+	ret, err := s.uc.ClaimTasks(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*pb.Task, 0, len(ret))
+	for _, v := range ret {
+		task := &pb.Task{
+			TaskType: v.TaskType,
+			TaskID:   v.TaskID,
+			ExpireAt: v.ExpireAt.Format(time.DateTime),
+		}
+		if v.Review != nil {
+			task.Review = toReviewInfo(v.Review)
+		}
+		if v.Appeal != nil {
+			task.Appeal = toAppealInfo(v.Appeal)
+		}
+		list = append(list, task)
+	}
+	return &pb.ClaimTasksReply{List: list}, nil
+}
+
+func (s *OperationService) RenewTasks(ctx context.Context, req *pb.RenewTasksRequest) (*pb.RenewTasksReply, error) {
+	renewed, expireAt, err := s.uc.RenewTasks(ctx, req.GetOpUser(), req.GetTaskType(), req.GetTaskIDs())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RenewTasksReply{TaskIDs: renewed, ExpireAt: expireAt.Format(time.DateTime)}, nil
+}
+
+func (s *OperationService) ReleaseTasks(ctx context.Context, req *pb.ReleaseTasksRequest) (*pb.ReleaseTasksReply, error) {
+	released, err := s.uc.ReleaseTasks(ctx, req.GetOpUser(), req.GetTaskType(), req.GetTaskIDs())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ReleaseTasksReply{TaskIDs: released}, nil
+}
+
+func toReviewInfo(v *biz.ReviewInfo) *pb.ReviewInfo {
+	return &pb.ReviewInfo{
+		ReviewID:     v.ReviewID,
+		UserID:       v.UserID,
+		OrderID:      v.OrderID,
+		StoreID:      v.StoreID,
+		SkuID:        v.SkuID,
+		Score:        v.Score,
+		ServiceScore: v.ServiceScore,
+		ExpressScore: v.ExpressScore,
+		Content:      v.Content,
+		PicInfo:      v.PicInfo,
+		VideoInfo:    v.VideoInfo,
+		HasMedia:     v.HasMedia,
+		Status:       v.Status,
+		OpReason:     v.OpReason,
+		Version:      v.Version,
+		CreateAt:     v.CreateAt,
+	}
+}
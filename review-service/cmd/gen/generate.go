@@ -79,6 +79,9 @@ func main() {
 	// gormdb, _ := gorm.Open(mysql.Open("root:@(127.0.0.1:3306)/demo?charset=utf8mb4&parseTime=True&loc=Local"))
 	g.UseDB(connectDB(bc.Data.Database)) // reuse your gorm db
 
+	// 逻辑删除字段使用gorm.DeletedAt，查询时自动过滤已删除的数据，调用Delete时只写入删除时间
+	g.WithOpts(gen.FieldType("delete_at", "gorm.DeletedAt"))
+
 	// Generate basic type-safe DAO API for struct `model.User` following conventions
 	g.ApplyBasic(g.GenerateAllTable()...)
 
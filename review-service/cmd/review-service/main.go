@@ -14,6 +14,7 @@ import (
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 	"github.com/go-kratos/kratos/v2/transport/http"
 	"review-service/internal/conf"
+	"review-service/internal/server"
 
 	_ "go.uber.org/automaxprocs"
 )
@@ -34,7 +35,7 @@ func init() {
 	flag.StringVar(&flagconf, "conf", "../../configs", "config path, eg: -conf config.yaml")
 }
 
-func newApp(logger log.Logger, r registry.Registrar, gs *grpc.Server, hs *http.Server) *kratos.App {
+func newApp(logger log.Logger, r registry.Registrar, gs *grpc.Server, hs *http.Server, ihs *server.InternalHTTPServer) *kratos.App {
 	return kratos.New(
 		kratos.ID(id),
 		kratos.Name(Name),
@@ -44,6 +45,7 @@ func newApp(logger log.Logger, r registry.Registrar, gs *grpc.Server, hs *http.S
 		kratos.Server(
 			gs,
 			hs,
+			ihs,
 		),
 		kratos.Registrar(r),
 	)
@@ -82,7 +84,7 @@ func main() {
 		panic(err)
 	}
 
-	app, cleanup, err := wireApp(bc.Server, &rc, bc.Elasticsearch, bc.Data, logger)
+	app, cleanup, err := wireApp(bc.Server, &rc, bc.Elasticsearch, bc.Data, bc.Sensitive, bc.Review, logger)
 	if err != nil {
 		panic(err)
 	}
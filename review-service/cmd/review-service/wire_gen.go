@@ -23,7 +23,7 @@ import (
 // Injectors from wire.go:
 
 // wireApp init kratos application.
-func wireApp(confServer *conf.Server, registry *conf.Registry, elasticsearch *conf.Elasticsearch, confData *conf.Data, logger log.Logger) (*kratos.App, func(), error) {
+func wireApp(confServer *conf.Server, registry *conf.Registry, elasticsearch *conf.Elasticsearch, confData *conf.Data, sensitive *conf.Sensitive, review *conf.Review, logger log.Logger) (*kratos.App, func(), error) {
 	registrar := server.NewRegister(registry)
 	db, err := data.NewDB(confData)
 	if err != nil {
@@ -39,12 +39,24 @@ func wireApp(confServer *conf.Server, registry *conf.Registry, elasticsearch *co
 		return nil, nil, err
 	}
 	reviewRepo := data.NewReviewRepo(dataData, logger)
-	reviewUsecase := biz.NewReviewUsecase(reviewRepo, logger)
+	discovery := data.NewDiscovery(registry)
+	orderClient := data.NewOrderServiceClient(discovery)
+	orderRepo := data.NewOrderRepo(orderClient, logger)
+	goodsClient := data.NewGoodsServiceClient(discovery)
+	goodsRepo := data.NewGoodsRepo(goodsClient, logger)
+	filter, cleanup2, err := data.NewSensitiveFilter(sensitive)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	reviewUsecase := biz.NewReviewUsecase(reviewRepo, orderRepo, goodsRepo, filter, review, logger)
 	reviewService := service.NewReviewService(reviewUsecase)
 	grpcServer := server.NewGRPCServer(confServer, reviewService, logger)
 	httpServer := server.NewHTTPServer(confServer, reviewService, logger)
-	app := newApp(logger, registrar, grpcServer, httpServer)
+	internalHTTPServer := server.NewInternalHTTPServer(confServer, reviewService, logger)
+	app := newApp(logger, registrar, grpcServer, httpServer, internalHTTPServer)
 	return app, func() {
+		cleanup2()
 		cleanup()
 	}, nil
 }
@@ -0,0 +1,68 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"review-service/internal/conf"
+	"review-service/internal/data/model"
+)
+
+// defaultAppealMaxSubmitTimes 没有配置时同一条评价最多提交申诉的次数
+const defaultAppealMaxSubmitTimes = 3
+
+func appealMaxSubmitTimes(cfg *conf.Review) int32 {
+	if n := cfg.GetAppealMaxSubmitTimes(); n > 0 {
+		return n
+	}
+	return defaultAppealMaxSubmitTimes
+}
+
+// WithdrawAppeal 商家撤回待审核的申诉，撤回后仍计入提交次数
+func (uc *ReviewUsecase) WithdrawAppeal(ctx context.Context, param *WithdrawAppealParam) error {
+	uc.log.WithContext(ctx).Debugf("[biz] WithdrawAppeal param:%v", param)
+	appeal, err := uc.repo.GetAppeal(ctx, param.AppealID)
+	if err != nil {
+		return err
+	}
+	// 水平越权校验，商家只能撤回自己店铺的申诉
+	if appeal.StoreID != param.StoreID {
+		return errors.New("水平越权")
+	}
+	if err := CheckAppealStatus(appeal.Status, AppealStatusWithdrawn); err != nil {
+		return err
+	}
+	if err := CheckVersion(param.ExpectedVersion, appeal.Version); err != nil {
+		return err
+	}
+	param.Version = appeal.Version
+	param.ReviewID = appeal.ReviewID
+	return uc.repo.WithdrawAppeal(ctx, param)
+}
+
+// GetAppeal 商家查询自己店铺的申诉详情
+func (uc *ReviewUsecase) GetAppeal(ctx context.Context, appealID, storeID int64) (*model.ReviewAppealInfo, error) {
+	uc.log.WithContext(ctx).Debugf("[biz] GetAppeal appealID:%v storeID:%v", appealID, storeID)
+	appeal, err := uc.repo.GetAppeal(ctx, appealID)
+	if err != nil {
+		return nil, err
+	}
+	// 水平越权校验，商家只能查看自己店铺的申诉
+	if appeal.StoreID != storeID {
+		return nil, errors.New("水平越权")
+	}
+	return appeal, nil
+}
+
+// ListAppealByStoreID 分页查询店铺的申诉，status为0时查询全部状态
+func (uc *ReviewUsecase) ListAppealByStoreID(ctx context.Context, storeID int64, status int32, page, size int) ([]*model.ReviewAppealInfo, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if size <= 0 || size > 50 {
+		size = 10
+	}
+	offset := (page - 1) * size
+	limit := size
+	uc.log.WithContext(ctx).Debugf("[biz] ListAppealByStoreID storeID:%v status:%v", storeID, status)
+	return uc.repo.ListAppealByStoreID(ctx, storeID, status, offset, limit)
+}
@@ -0,0 +1,126 @@
+package biz
+
+import (
+	"context"
+	"review-service/internal/conf"
+	"review-service/internal/data/model"
+	"time"
+)
+
+// 申诉审核时效状态，根据审核截止时间实时计算，不落库
+const (
+	AppealSLANone      int32 = 0 // 申诉已处理，不再计算时效
+	AppealSLANormal    int32 = 1 // 未超时
+	AppealSLAOverdue   int32 = 2 // 已超时，等待升级
+	AppealSLAEscalated int32 = 3 // 已超时并升级到高级审核队列
+)
+
+// defaultAppealAuditTimeout 没有配置时申诉的审核时长
+const defaultAppealAuditTimeout = 48 * time.Hour
+
+// autoCloseAppealReason 超时自动关闭申诉时记录的原因
+const autoCloseAppealReason = "超时未审核，系统自动关闭"
+
+// appealSLAPolicy 申诉审核时效策略
+type appealSLAPolicy struct {
+	auditTimeout       time.Duration
+	reasonAuditTimeout map[string]time.Duration
+	closeTimeout       time.Duration // 为0时不自动关闭
+}
+
+func newAppealSLAPolicy(cfg *conf.AppealSLA) *appealSLAPolicy {
+	p := &appealSLAPolicy{
+		auditTimeout:       cfg.GetAuditTimeout().AsDuration(),
+		reasonAuditTimeout: make(map[string]time.Duration, len(cfg.GetReasonAuditTimeout())),
+		closeTimeout:       cfg.GetCloseTimeout().AsDuration(),
+	}
+	if p.auditTimeout <= 0 {
+		p.auditTimeout = defaultAppealAuditTimeout
+	}
+	for reason, d := range cfg.GetReasonAuditTimeout() {
+		p.reasonAuditTimeout[reason] = d.AsDuration()
+	}
+	return p
+}
+
+// deadline 计算申诉的审核截止时间，申诉原因类别单独配置了时长的优先使用
+func (p *appealSLAPolicy) deadline(reason string, submitAt time.Time) time.Time {
+	if d, ok := p.reasonAuditTimeout[reason]; ok && d > 0 {
+		return submitAt.Add(d)
+	}
+	return submitAt.Add(p.auditTimeout)
+}
+
+// AppealSLAStatus 计算申诉当前的审核时效状态
+func AppealSLAStatus(appeal *model.ReviewAppealInfo, now time.Time) int32 {
+	switch {
+	case appeal.Status != AppealStatusPending:
+		return AppealSLANone
+	case appeal.EscalateAt != nil:
+		return AppealSLAEscalated
+	case now.After(appeal.DeadlineAt):
+		return AppealSLAOverdue
+	default:
+		return AppealSLANormal
+	}
+}
+
+// maxAppealSLALimit CheckAppealSLA每次最多处理的申诉数
+const maxAppealSLALimit = 500
+
+// CheckAppealSLA 扫描超时的申诉，由review-job定时调用
+// 超过审核截止时间的申诉升级到高级审核队列；配置了自动关闭时，超过截止时间一段时间仍未审核的申诉自动关闭
+// 每次最多处理limit条，返回本次升级和关闭的数量，调用方在数量达到limit时继续调用
+func (uc *ReviewUsecase) CheckAppealSLA(ctx context.Context, limit int) (escalated, closed int, err error) {
+	uc.log.WithContext(ctx).Debugf("[biz] CheckAppealSLA limit:%v", limit)
+	// 超过上限时按上限处理，调用方按返回数量是否达到limit判断是否继续，不能改成比上限更小的值
+	switch {
+	case limit <= 0:
+		limit = 100
+	case limit > maxAppealSLALimit:
+		limit = maxAppealSLALimit
+	}
+	now := time.Now()
+	n, err := uc.repo.EscalateOverdueAppeals(ctx, now, limit)
+	if err != nil {
+		return 0, 0, err
+	}
+	escalated = int(n)
+	if uc.appealSLA.closeTimeout <= 0 {
+		return escalated, 0, nil
+	}
+	appeals, err := uc.repo.ListOverdueAppeals(ctx, now.Add(-uc.appealSLA.closeTimeout), limit)
+	if err != nil {
+		return escalated, 0, err
+	}
+	for _, appeal := range appeals {
+		err := uc.repo.CloseAppeal(ctx, &CloseAppealParam{
+			AppealID: appeal.AppealID,
+			ReviewID: appeal.ReviewID,
+			OpUser:   OpActorSystem,
+			OpReason: autoCloseAppealReason,
+			Version:  appeal.Version,
+		})
+		// 关闭前刚好被运营审核或者商家撤回，跳过即可
+		if err != nil {
+			uc.log.WithContext(ctx).Warnf("[biz] CheckAppealSLA close appeal:%d fail, err:%v", appeal.AppealID, err)
+			continue
+		}
+		closed++
+	}
+	return escalated, closed, nil
+}
+
+// ListEscalatedAppeals 分页查询高级审核队列中的申诉，按审核截止时间先后排序
+func (uc *ReviewUsecase) ListEscalatedAppeals(ctx context.Context, page, size int) ([]*model.ReviewAppealInfo, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if size <= 0 || size > 50 {
+		size = 10
+	}
+	offset := (page - 1) * size
+	limit := size
+	uc.log.WithContext(ctx).Debugf("[biz] ListEscalatedAppeals page:%v size:%v", page, size)
+	return uc.repo.ListEscalatedAppeals(ctx, offset, limit)
+}
@@ -0,0 +1,34 @@
+package biz
+
+import (
+	"context"
+	"review-service/internal/data/model"
+)
+
+// ListPendingReviews O端待审核评价队列，按评价时间先后排序，先提交的先审核
+func (uc *ReviewUsecase) ListPendingReviews(ctx context.Context, param *ListPendingReviewParam, page, size int) ([]*model.ReviewInfo, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if size <= 0 || size > 100 {
+		size = 20
+	}
+	offset := (page - 1) * size
+	limit := size
+	uc.log.WithContext(ctx).Debugf("[biz] ListPendingReviews param:%#v page:%v size:%v", param, page, size)
+	return uc.repo.ListPendingReviews(ctx, param, offset, limit)
+}
+
+// ListPendingAppeals O端待审核申诉队列，按审核截止时间先后排序，快超时的先审核
+func (uc *ReviewUsecase) ListPendingAppeals(ctx context.Context, param *ListPendingAppealParam, page, size int) ([]*model.ReviewAppealInfo, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if size <= 0 || size > 100 {
+		size = 20
+	}
+	offset := (page - 1) * size
+	limit := size
+	uc.log.WithContext(ctx).Debugf("[biz] ListPendingAppeals param:%#v page:%v size:%v", param, page, size)
+	return uc.repo.ListPendingAppeals(ctx, param, offset, limit)
+}
@@ -0,0 +1,84 @@
+package biz
+
+import (
+	"context"
+	v1 "review-service/api/review/v1"
+)
+
+// maxBatchAuditSize 批量审核一次最多处理的数量
+const maxBatchAuditSize = 100
+
+// BatchAuditResult 批量审核中单条的结果
+type BatchAuditResult struct {
+	ID  int64 // 评价ID或申诉ID
+	Err error // 为nil表示审核成功
+}
+
+// BatchAuditReview 批量审核评价
+// 每条评价单独按状态机校验并在各自的事务中更新，单条失败不影响其他评价，失败原因在结果中逐条返回
+func (uc *ReviewUsecase) BatchAuditReview(ctx context.Context, param *BatchAuditReviewParam) ([]*BatchAuditResult, error) {
+	uc.log.WithContext(ctx).Debugf("[biz] BatchAuditReview param:%v", param)
+	ids, err := checkBatchIDs(param.ReviewIDs)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*BatchAuditResult, 0, len(ids))
+	for _, id := range ids {
+		err := uc.AuditReview(ctx, &AuditParam{
+			ReviewID:  id,
+			OpUser:    param.OpUser,
+			OpReason:  param.OpReason,
+			OpRemarks: param.OpRemarks,
+			Status:    param.Status,
+		})
+		results = append(results, &BatchAuditResult{ID: id, Err: err})
+	}
+	return results, nil
+}
+
+// BatchAuditAppeal 批量审核申诉
+// 批量审核时只传申诉ID，评价和店铺按申诉记录填充，其余校验和单条审核一致
+func (uc *ReviewUsecase) BatchAuditAppeal(ctx context.Context, param *BatchAuditAppealParam) ([]*BatchAuditResult, error) {
+	uc.log.WithContext(ctx).Debugf("[biz] BatchAuditAppeal param:%v", param)
+	if param.Status != AppealStatusApproved && param.Status != AppealStatusRejected {
+		return nil, v1.ErrorInvalidParam("申诉审核结果:%d不合法", param.Status)
+	}
+	ids, err := checkBatchIDs(param.AppealIDs)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*BatchAuditResult, 0, len(ids))
+	for _, id := range ids {
+		appeal, err := uc.repo.GetAppeal(ctx, id)
+		if err == nil {
+			err = uc.AuditAppeal(ctx, &AuditAppealParam{
+				AppealID:  id,
+				ReviewID:  appeal.ReviewID,
+				StoreID:   appeal.StoreID,
+				OpUser:    param.OpUser,
+				OpReason:  param.OpReason,
+				OpRemarks: param.OpRemarks,
+				Status:    param.Status,
+			})
+		}
+		results = append(results, &BatchAuditResult{ID: id, Err: err})
+	}
+	return results, nil
+}
+
+// checkBatchIDs 校验批量操作的数量并去掉重复的ID，保持原有顺序
+func checkBatchIDs(ids []int64) ([]int64, error) {
+	if len(ids) == 0 || len(ids) > maxBatchAuditSize {
+		return nil, v1.ErrorInvalidParam("批量审核的数量必须在1到%d之间", maxBatchAuditSize)
+	}
+	seen := make(map[int64]struct{}, len(ids))
+	ret := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ret = append(ret, id)
+	}
+	return ret, nil
+}
@@ -0,0 +1,92 @@
+package biz
+
+import (
+	"context"
+	v1 "review-service/api/review/v1"
+	"review-service/internal/conf"
+	"review-service/internal/data/model"
+	"review-service/pkg/snowflake"
+	"time"
+)
+
+// 默认评价：订单完成后超过一定时间用户没有评价，系统自动给出好评
+const (
+	defaultReviewScore   int32 = 5
+	defaultReviewContent       = "用户超时未做出评价，系统默认好评"
+
+	defaultDefaultReviewDays = 15 // 没有配置时订单完成后多少天未评价才创建默认好评
+)
+
+func defaultReviewAfter(cfg *conf.Review) time.Duration {
+	days := cfg.GetDefaultReviewDays()
+	if days <= 0 {
+		days = defaultDefaultReviewDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// CreateDefaultReviews 为超时未评价的订单商品创建默认好评
+// 订单、店铺和商品信息以订单服务和商品服务为准，请求中的店铺和spu只用于定位，不直接入库
+// 订单不存在、不属于该用户、未完成或者还没到默认好评时间的商品跳过
+// 已经评价过的订单商品直接跳过，重复调用不会重复创建，返回本次新创建的评价
+func (uc *ReviewUsecase) CreateDefaultReviews(ctx context.Context, items []*model.ReviewInfo) ([]*model.ReviewInfo, error) {
+	uc.log.WithContext(ctx).Debugf("[biz] CreateDefaultReviews, len(items):%d", len(items))
+	created := make([]*model.ReviewInfo, 0, len(items))
+	orders := make(map[int64]*Order) // 同一个订单的多个商品只查询一次订单
+	for _, item := range items {
+		existed, err := uc.repo.GetReviewByOrderSku(ctx, item.OrderID, item.SkuID)
+		if err != nil {
+			return created, v1.ErrorDbFailed("查询数据库失败")
+		}
+		// 用户删除过评价的订单商品也不再创建默认好评
+		if len(existed) > 0 {
+			continue
+		}
+		order, ok := orders[item.OrderID]
+		if !ok {
+			order, err = uc.checkOrder(ctx, item.OrderID, item.UserID)
+			if v1.IsInvalidParam(err) {
+				uc.log.WithContext(ctx).Warnf("[biz] CreateDefaultReviews skip order:%d, err:%v", item.OrderID, err)
+				continue
+			}
+			if err != nil {
+				return created, err
+			}
+			orders[item.OrderID] = order
+		}
+		if time.Since(order.CompleteAt) < uc.defaultReviewAfter {
+			uc.log.WithContext(ctx).Warnf("[biz] CreateDefaultReviews skip order:%d, completed at %v", item.OrderID, order.CompleteAt)
+			continue
+		}
+		review := &model.ReviewInfo{
+			ReviewID:     snowflake.GenID(),
+			OrderID:      order.OrderID,
+			UserID:       order.UserID,
+			SkuID:        item.SkuID,
+			Score:        defaultReviewScore,
+			ServiceScore: defaultReviewScore,
+			ExpressScore: defaultReviewScore,
+			Content:      defaultReviewContent,
+			IsDefault:    1,
+			// 默认评价的内容是系统模板，不需要审核
+			Status: ReviewStatusApproved,
+			OpUser: autoAuditOpUser,
+		}
+		if err := uc.fillGoodsSnapshot(ctx, review, order); err != nil {
+			if v1.IsInvalidParam(err) {
+				uc.log.WithContext(ctx).Warnf("[biz] CreateDefaultReviews skip order:%d sku:%d, err:%v", item.OrderID, item.SkuID, err)
+				continue
+			}
+			return created, err
+		}
+		if _, err := uc.repo.SaveReview(ctx, review); err != nil {
+			// 用户刚好在这期间提交了评价，由唯一索引兜底，跳过即可
+			if v1.IsOrderReviewed(err) {
+				continue
+			}
+			return created, err
+		}
+		created = append(created, review)
+	}
+	return created, nil
+}
@@ -0,0 +1,45 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+// DeleteReview 用户删除自己的评价
+// 逻辑删除，评价的回复、申诉和追评一起删除
+func (uc *ReviewUsecase) DeleteReview(ctx context.Context, param *DeleteReviewParam) error {
+	uc.log.WithContext(ctx).Debugf("[biz] DeleteReview param:%v", param)
+	review, err := uc.repo.GetReview(ctx, param.ReviewID)
+	if err != nil {
+		return err
+	}
+	// 水平越权校验，用户只能删除自己的评价
+	if review.UserID != param.UserID {
+		return errors.New("水平越权")
+	}
+	if err := CheckVersion(param.ExpectedVersion, review.Version); err != nil {
+		return err
+	}
+	param.Version = review.Version
+	param.UpdateBy = strconv.FormatInt(param.UserID, 10)
+	return uc.repo.DeleteReview(ctx, param)
+}
+
+// ForceDeleteReview 运营强制删除评价
+func (uc *ReviewUsecase) ForceDeleteReview(ctx context.Context, param *DeleteReviewParam) error {
+	uc.log.WithContext(ctx).Debugf("[biz] ForceDeleteReview param:%v", param)
+	if len(param.OpUser) == 0 {
+		return errors.New("运营者标识不能为空")
+	}
+	review, err := uc.repo.GetReview(ctx, param.ReviewID)
+	if err != nil {
+		return err
+	}
+	if err := CheckVersion(param.ExpectedVersion, review.Version); err != nil {
+		return err
+	}
+	param.Version = review.Version
+	param.UpdateBy = param.OpUser
+	return uc.repo.DeleteReview(ctx, param)
+}
@@ -0,0 +1,109 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	v1 "review-service/api/review/v1"
+	"review-service/internal/conf"
+	"review-service/internal/data/model"
+	"review-service/pkg/snowflake"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// defaultFollowUpWindow 未配置时评价创建后允许追评的时间窗口
+const defaultFollowUpWindow = 180 * 24 * time.Hour
+
+func followUpWindow(cfg *conf.Review) time.Duration {
+	if d := cfg.GetFollowUpWindow().AsDuration(); d > 0 {
+		return d
+	}
+	return defaultFollowUpWindow
+}
+
+// CreateFollowUp 创建追评
+// 追评挂在已有的评价下面，每条评价只能追评一次
+func (uc *ReviewUsecase) CreateFollowUp(ctx context.Context, param *FollowUpParam) (*model.ReviewFollowUpInfo, error) {
+	uc.log.WithContext(ctx).Debugf("[biz] CreateFollowUp param:%v", param)
+	// 1、数据校验
+	// 1.1 追评的评价必须存在，并且是当前用户自己的评价
+	review, err := uc.repo.GetReview(ctx, param.ReviewID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, v1.ErrorFollowUpNotAllowed("评价:%d不存在", param.ReviewID)
+	}
+	if err != nil {
+		return nil, v1.ErrorDbFailed("查询数据库失败")
+	}
+	if review.UserID != param.UserID {
+		return nil, v1.ErrorFollowUpNotAllowed("不能追评他人的评价")
+	}
+	// 1.2 审核不通过或者已隐藏的评价不能追评
+	if review.Status == ReviewStatusRejected || review.Status == ReviewStatusHidden {
+		return nil, v1.ErrorFollowUpNotAllowed("评价:%d当前状态不能追评", param.ReviewID)
+	}
+	// 1.3 超过追评时间窗口
+	if time.Since(review.CreateAt) > uc.followUpWindow {
+		return nil, v1.ErrorFollowUpNotAllowed("评价:%d已超过追评期限", param.ReviewID)
+	}
+	// 1.4 已经追评过
+	followUps, err := uc.repo.GetFollowUpByReviewID(ctx, param.ReviewID)
+	if err != nil {
+		return nil, v1.ErrorDbFailed("查询数据库失败")
+	}
+	if len(followUps) > 0 {
+		return nil, v1.ErrorFollowUpNotAllowed("评价:%d已追评", param.ReviewID)
+	}
+	// 2、拼装数据
+	followUp := &model.ReviewFollowUpInfo{
+		FollowUpID: snowflake.GenID(),
+		ReviewID:   review.ReviewID,
+		UserID:     review.UserID,
+		StoreID:    review.StoreID,
+		Content:    param.Content,
+		PicInfo:    param.PicInfo,
+		VideoInfo:  param.VideoInfo,
+	}
+	// 3、内容机审，和评价一样：明确违规直接拒绝，干净的纯文字追评直接通过，其余转人工审核
+	uc.moderateFollowUp(followUp)
+	// 4、入库
+	return uc.repo.SaveFollowUp(ctx, followUp)
+}
+
+// GetFollowUp 根据追评ID获取追评
+func (uc *ReviewUsecase) GetFollowUp(ctx context.Context, followUpID int64) (*model.ReviewFollowUpInfo, error) {
+	uc.log.WithContext(ctx).Debugf("[biz] GetFollowUp followUpID:%v", followUpID)
+	return uc.repo.GetFollowUp(ctx, followUpID)
+}
+
+// ListFollowUpByUserID 根据userID分页查询追评
+func (uc *ReviewUsecase) ListFollowUpByUserID(ctx context.Context, userID int64, page, size int) ([]*model.ReviewFollowUpInfo, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if size <= 0 || size > 50 {
+		size = 10
+	}
+	offset := (page - 1) * size
+	limit := size
+	uc.log.WithContext(ctx).Debugf("[biz] ListFollowUpByUserID userID:%v", userID)
+	return uc.repo.ListFollowUpByUserID(ctx, userID, offset, limit)
+}
+
+// AuditFollowUp 审核追评
+func (uc *ReviewUsecase) AuditFollowUp(ctx context.Context, param *AuditFollowUpParam) error {
+	uc.log.WithContext(ctx).Debugf("[biz] AuditFollowUp param:%v", param)
+	followUp, err := uc.repo.GetFollowUp(ctx, param.FollowUpID)
+	if err != nil {
+		return err
+	}
+	// 追评和评价使用同一套状态机
+	if err := CheckReviewStatus(followUp.Status, param.Status); err != nil {
+		return err
+	}
+	if err := CheckVersion(param.ExpectedVersion, followUp.Version); err != nil {
+		return err
+	}
+	param.Version = followUp.Version
+	return uc.repo.AuditFollowUp(ctx, param)
+}
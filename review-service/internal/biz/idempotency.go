@@ -0,0 +1,57 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"review-service/internal/data/model"
+)
+
+// reviewCtrl 评价的控制信息，保存在review_info.ctrl_json中
+type reviewCtrl struct {
+	IdempotencyKey string `json:"idempotency_key,omitempty"` // 客户端的幂等键
+}
+
+func marshalReviewCtrl(ctrl *reviewCtrl) string {
+	if ctrl == nil || len(ctrl.IdempotencyKey) == 0 {
+		return ""
+	}
+	b, _ := json.Marshal(ctrl)
+	return string(b)
+}
+
+func unmarshalReviewCtrl(s string) *reviewCtrl {
+	ctrl := new(reviewCtrl)
+	if len(s) > 0 {
+		_ = json.Unmarshal([]byte(s), ctrl)
+	}
+	return ctrl
+}
+
+// findRetriedReview 在已有的评价中找到同一个幂等键创建的评价，说明是客户端重试
+func findRetriedReview(reviews []*model.ReviewInfo, userID int64, idempotencyKey string) *model.ReviewInfo {
+	if len(idempotencyKey) == 0 {
+		return nil
+	}
+	for _, v := range reviews {
+		if v.UserID == userID && !v.DeleteAt.Valid && unmarshalReviewCtrl(v.CtrlJSON).IdempotencyKey == idempotencyKey {
+			return v
+		}
+	}
+	return nil
+}
+
+// getReviewByIdempotencyKey 先查缓存中幂等键对应的评价，缓存不可用时返回nil，由数据库兜底
+func (uc *ReviewUsecase) getReviewByIdempotencyKey(ctx context.Context, userID int64, idempotencyKey string) *model.ReviewInfo {
+	if len(idempotencyKey) == 0 {
+		return nil
+	}
+	reviewID, err := uc.repo.GetIdempotentReviewID(ctx, userID, idempotencyKey)
+	if err != nil || reviewID == 0 {
+		return nil
+	}
+	review, err := uc.repo.GetReview(ctx, reviewID)
+	if err != nil {
+		return nil
+	}
+	return review
+}
@@ -0,0 +1,89 @@
+package biz
+
+import (
+	"encoding/json"
+	"fmt"
+	"review-service/internal/data/model"
+	"review-service/pkg/sensitive"
+	"strings"
+)
+
+// autoAuditOpUser 机审的运营者标识
+const autoAuditOpUser = "system"
+
+// moderation 内容机审结果
+type moderation struct {
+	Status   int32
+	OpReason string
+	ExtJSON  string
+	Hits     []sensitive.Hit
+}
+
+// blocked 是否命中了明确违规的敏感词
+func (m *moderation) blocked() bool {
+	return m.Status == ReviewStatusRejected
+}
+
+// moderate 对用户输入的内容进行机审
+// 命中明确违规词直接拒绝，命中疑似违规词或者带图片视频的内容转人工审核，其余自动通过
+func (uc *ReviewUsecase) moderate(content string, hasMedia bool) *moderation {
+	m := &moderation{Status: ReviewStatusApproved}
+	if uc.filter != nil {
+		m.Hits = uc.filter.Match(content)
+	}
+	words := make([]string, 0, len(m.Hits))
+	blocked := false
+	for _, hit := range m.Hits {
+		words = append(words, hit.Word)
+		if hit.Level == sensitive.LevelBlock {
+			blocked = true
+		}
+	}
+	switch {
+	case blocked:
+		m.Status = ReviewStatusRejected
+		m.OpReason = fmt.Sprintf("包含违禁词:%s", strings.Join(words, ","))
+	case len(words) > 0:
+		m.Status = ReviewStatusPending
+		m.OpReason = fmt.Sprintf("疑似敏感词:%s", strings.Join(words, ","))
+	case hasMedia:
+		// 图片和视频机审识别不了，需要人工审核
+		m.Status = ReviewStatusPending
+	}
+	if len(words) > 0 {
+		b, _ := json.Marshal(map[string]interface{}{"sensitive_words": words})
+		m.ExtJSON = string(b)
+	}
+	return m
+}
+
+// moderateReview 评价入库前机审，根据机审结果设置评价的状态
+func (uc *ReviewUsecase) moderateReview(review *model.ReviewInfo) {
+	review.HasMedia = 0
+	if len(review.PicInfo) > 0 || len(review.VideoInfo) > 0 {
+		review.HasMedia = 1
+	}
+	m := uc.moderate(review.Content, review.HasMedia == 1)
+	review.Status = m.Status
+	review.OpReason = m.OpReason
+	review.ExtJSON = m.ExtJSON
+	// 机审直接给出结论的评价记录机审标识，转人工的由运营审核时填写
+	if m.Status != ReviewStatusPending {
+		review.OpUser = autoAuditOpUser
+	}
+}
+
+// moderateFollowUp 追评入库前机审，规则和评价一致
+func (uc *ReviewUsecase) moderateFollowUp(followUp *model.ReviewFollowUpInfo) {
+	followUp.HasMedia = 0
+	if len(followUp.PicInfo) > 0 || len(followUp.VideoInfo) > 0 {
+		followUp.HasMedia = 1
+	}
+	m := uc.moderate(followUp.Content, followUp.HasMedia == 1)
+	followUp.Status = m.Status
+	followUp.OpReason = m.OpReason
+	followUp.ExtJSON = m.ExtJSON
+	if m.Status != ReviewStatusPending {
+		followUp.OpUser = autoAuditOpUser
+	}
+}
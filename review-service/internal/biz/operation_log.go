@@ -0,0 +1,48 @@
+package biz
+
+import (
+	"context"
+	v1 "review-service/api/review/v1"
+	"review-service/internal/data/model"
+)
+
+// 操作对象类型 review_operation_log.target_type
+const (
+	OpTargetReview   int32 = 1 // 评价
+	OpTargetReply    int32 = 2 // 回复
+	OpTargetAppeal   int32 = 3 // 申诉
+	OpTargetFollowUp int32 = 4 // 追评
+)
+
+// 操作类型 review_operation_log.action
+const (
+	OpActionCreate   int32 = 1 // 创建
+	OpActionAudit    int32 = 2 // 审核
+	OpActionUpdate   int32 = 3 // 修改
+	OpActionDelete   int32 = 4 // 删除
+	OpActionWithdraw int32 = 5 // 撤回
+	OpActionEscalate int32 = 6 // 超时升级
+	OpActionClose    int32 = 7 // 超时关闭
+	OpActionHide     int32 = 8 // 申诉通过隐藏
+)
+
+// 操作人角色 review_operation_log.role
+const (
+	OpRoleUser     int32 = 1 // 用户
+	OpRoleMerchant int32 = 2 // 商家
+	OpRoleOperator int32 = 3 // 运营
+	OpRoleSystem   int32 = 4 // 系统
+)
+
+// OpActorSystem 系统操作（机审、默认评价、定时任务）记录的操作人标识
+const OpActorSystem = autoAuditOpUser
+
+// GetReviewTimeline 查询评价及其回复、申诉、追评的全部操作记录，按操作先后排序
+func (uc *ReviewUsecase) GetReviewTimeline(ctx context.Context, reviewID int64) ([]*model.ReviewOperationLog, error) {
+	uc.log.WithContext(ctx).Debugf("[biz] GetReviewTimeline reviewID:%v", reviewID)
+	logs, err := uc.repo.ListOperationLogs(ctx, reviewID)
+	if err != nil {
+		return nil, v1.ErrorDbFailed("查询数据库失败")
+	}
+	return logs, nil
+}
@@ -0,0 +1,89 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	v1 "review-service/api/review/v1"
+	"review-service/internal/data/model"
+	"time"
+)
+
+// OrderStatusCompleted 订单已完成，只有已完成的订单才能评价
+const OrderStatusCompleted int32 = 40
+
+// Order 订单信息，来自订单服务
+type Order struct {
+	OrderID int64
+	UserID  int64
+	StoreID int64
+	Status  int32
+	Items   []*OrderItem
+
+	CompleteAt time.Time // 订单完成时间
+}
+
+// OrderItem 订单中的商品
+type OrderItem struct {
+	SkuID int64
+	SpuID int64
+}
+
+// Goods 商品信息，来自商品服务
+type Goods struct {
+	SkuID   int64  `json:"sku_id"`
+	SpuID   int64  `json:"spu_id"`
+	StoreID int64  `json:"store_id"`
+	Title   string `json:"title"`
+	Price   int64  `json:"price"` // 单位：分
+	Pic     string `json:"pic"`
+	Specs   string `json:"specs"` // 规格，比如 颜色:红色;尺码:XL
+}
+
+type OrderRepo interface {
+	GetOrder(ctx context.Context, orderID int64) (*Order, error)
+}
+
+type GoodsRepo interface {
+	GetGoods(ctx context.Context, skuID int64) (*Goods, error)
+}
+
+// checkOrder 校验订单属于当前用户并且已完成
+func (uc *ReviewUsecase) checkOrder(ctx context.Context, orderID, userID int64) (*Order, error) {
+	order, err := uc.order.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != userID {
+		return nil, v1.ErrorInvalidParam("不能评价他人的订单:%d", orderID)
+	}
+	if order.Status != OrderStatusCompleted {
+		return nil, v1.ErrorInvalidParam("订单:%d未完成，不能评价", orderID)
+	}
+	return order, nil
+}
+
+// fillGoodsSnapshot 根据订单和商品信息填充评价的店铺、商品和商品快照
+func (uc *ReviewUsecase) fillGoodsSnapshot(ctx context.Context, review *model.ReviewInfo, order *Order) error {
+	var item *OrderItem
+	for _, v := range order.Items {
+		if v.SkuID == review.SkuID {
+			item = v
+			break
+		}
+	}
+	if item == nil {
+		return v1.ErrorInvalidParam("订单:%d中没有商品:%d", order.OrderID, review.SkuID)
+	}
+	goods, err := uc.goods.GetGoods(ctx, item.SkuID)
+	if err != nil {
+		return err
+	}
+	snapshot, err := json.Marshal(goods)
+	if err != nil {
+		return err
+	}
+	review.StoreID = order.StoreID
+	review.SpuID = item.SpuID
+	review.GoodsSnapshoot = string(snapshot)
+	return nil
+}
@@ -0,0 +1,107 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	v1 "review-service/api/review/v1"
+	"review-service/internal/data/model"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// memOrderRepo 基于内存的订单数据
+type memOrderRepo map[int64]*Order
+
+func (r memOrderRepo) GetOrder(ctx context.Context, orderID int64) (*Order, error) {
+	order, ok := r[orderID]
+	if !ok {
+		return nil, v1.ErrorInvalidParam("订单:%d不存在", orderID)
+	}
+	return order, nil
+}
+
+// memGoodsRepo 基于内存的商品数据
+type memGoodsRepo map[int64]*Goods
+
+func (r memGoodsRepo) GetGoods(ctx context.Context, skuID int64) (*Goods, error) {
+	goods, ok := r[skuID]
+	if !ok {
+		return nil, v1.ErrorInvalidParam("商品:%d不存在", skuID)
+	}
+	return goods, nil
+}
+
+func newOrderTestUsecase() *ReviewUsecase {
+	return &ReviewUsecase{
+		order: memOrderRepo{
+			1: {OrderID: 1, UserID: 11, StoreID: 21, Status: OrderStatusCompleted,
+				Items: []*OrderItem{{SkuID: 101, SpuID: 201}}},
+			2: {OrderID: 2, UserID: 11, StoreID: 21, Status: 20,
+				Items: []*OrderItem{{SkuID: 101, SpuID: 201}}},
+		},
+		goods: memGoodsRepo{
+			101: {SkuID: 101, SpuID: 201, StoreID: 21, Title: "T恤", Price: 9900, Specs: "颜色:红色;尺码:XL"},
+		},
+		log: log.NewHelper(log.DefaultLogger),
+	}
+}
+
+func TestCheckOrder(t *testing.T) {
+	uc := newOrderTestUsecase()
+	tests := []struct {
+		name    string
+		orderID int64
+		userID  int64
+		wantErr bool
+	}{
+		{name: "order missing", orderID: 9, userID: 11, wantErr: true},
+		{name: "wrong user", orderID: 1, userID: 12, wantErr: true},
+		{name: "not completed", orderID: 2, userID: 11, wantErr: true},
+		{name: "ok", orderID: 1, userID: 11},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := uc.checkOrder(context.Background(), tt.orderID, tt.userID)
+			if tt.wantErr {
+				if !v1.IsInvalidParam(err) {
+					t.Fatalf("checkOrder(%d, %d) err = %v, want InvalidParam", tt.orderID, tt.userID, err)
+				}
+				return
+			}
+			if err != nil || order.OrderID != tt.orderID {
+				t.Fatalf("checkOrder(%d, %d) = %v, %v", tt.orderID, tt.userID, order, err)
+			}
+		})
+	}
+}
+
+func TestFillGoodsSnapshot(t *testing.T) {
+	uc := newOrderTestUsecase()
+	order, err := uc.checkOrder(context.Background(), 1, 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 评价的店铺、spu和商品快照以订单和商品服务为准
+	review := &model.ReviewInfo{OrderID: 1, UserID: 11, SkuID: 101, StoreID: 99, SpuID: 99}
+	if err := uc.fillGoodsSnapshot(context.Background(), review, order); err != nil {
+		t.Fatal(err)
+	}
+	if review.StoreID != 21 || review.SpuID != 201 {
+		t.Fatalf("store:%d spu:%d, want 21 and 201", review.StoreID, review.SpuID)
+	}
+	goods := &Goods{}
+	if err := json.Unmarshal([]byte(review.GoodsSnapshoot), goods); err != nil {
+		t.Fatalf("invalid goods snapshot %q: %v", review.GoodsSnapshoot, err)
+	}
+	if goods.Title != "T恤" || goods.Price != 9900 || goods.Specs != "颜色:红色;尺码:XL" {
+		t.Fatalf("goods snapshot = %+v", goods)
+	}
+
+	// 订单中没有的商品不能评价
+	review = &model.ReviewInfo{OrderID: 1, UserID: 11, SkuID: 102}
+	if err := uc.fillGoodsSnapshot(context.Background(), review, order); !v1.IsInvalidParam(err) {
+		t.Fatalf("fillGoodsSnapshot with sku not in order err = %v, want InvalidParam", err)
+	}
+}
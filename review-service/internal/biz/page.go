@@ -0,0 +1,40 @@
+package biz
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	v1 "review-service/api/review/v1"
+)
+
+// PageToken 游标分页的翻页令牌
+// 对客户端是不透明的，序列化成base64字符串后返回，下一页请求原样带回
+type PageToken struct {
+	LastID      int64         `json:"id,omitempty"`    // MySQL游标：上一页最后一条记录的自增id
+	PitID       string        `json:"pit,omitempty"`   // ES point in time id
+	SearchAfter []interface{} `json:"after,omitempty"` // ES search_after：上一页最后一条记录的排序值
+}
+
+// EncodePageToken 序列化翻页令牌，nil表示没有下一页
+func EncodePageToken(token *PageToken) string {
+	if token == nil {
+		return ""
+	}
+	b, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodePageToken 解析客户端带回的翻页令牌，空字符串表示不使用游标分页
+func DecodePageToken(s string) (*PageToken, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, v1.ErrorInvalidParam("无效的翻页令牌")
+	}
+	token := new(PageToken)
+	if err := json.Unmarshal(b, token); err != nil {
+		return nil, v1.ErrorInvalidParam("无效的翻页令牌")
+	}
+	return token, nil
+}
@@ -1,5 +1,7 @@
 package biz
 
+import "time"
+
 // ReplyParam 商家回复评价的参数
 type ReplyParam struct {
 	ReviewID  int64
@@ -7,6 +9,8 @@ type ReplyParam struct {
 	Content   string
 	PicInfo   string
 	VideoInfo string
+
+	ExpectedVersion *int32 // 客户端期望的评价版本号（乐观锁），为空时不校验
 }
 
 // AuditParam 运营审核评价的参数
@@ -16,6 +20,9 @@ type AuditParam struct {
 	OpReason  string
 	OpRemarks string
 	Status    int32
+
+	ExpectedVersion *int32 // 客户端期望的评价版本号（乐观锁），为空时不校验
+	Version         int32  // 评价当前版本号，由biz层填充，data层按此版本号条件更新
 }
 
 // AppealParam 商家申诉评价的参数
@@ -27,12 +34,149 @@ type AppealParam struct {
 	PicInfo   string
 	VideoInfo string
 	OpUser    string
+
+	ExpectedVersion *int32    // 重新提交待审核申诉时，客户端期望的申诉版本号
+	MaxSubmitTimes  int32     // 最多提交申诉的次数，由biz层填充
+	DeadlineAt      time.Time // 新提交申诉的审核截止时间，由biz层填充
+}
+
+// CloseAppealParam 超时自动关闭申诉的参数
+type CloseAppealParam struct {
+	AppealID int64
+	ReviewID int64
+	OpUser   string
+	OpReason string
+	Version  int32 // 申诉当前版本号
+}
+
+// WithdrawAppealParam 商家撤回申诉的参数
+type WithdrawAppealParam struct {
+	AppealID int64
+	StoreID  int64
+	ReviewID int64 // 申诉对应的评价，由biz层填充
+
+	ExpectedVersion *int32 // 客户端期望的申诉版本号（乐观锁），为空时不校验
+	Version         int32  // 申诉当前版本号
 }
 
 // AuditAppealParam O端审核商家申诉的参数
 type AuditAppealParam struct {
+	ReviewID  int64
+	StoreID   int64
+	AppealID  int64
+	OpUser    string
+	OpReason  string
+	OpRemarks string
+	Status    int32
+
+	ExpectedVersion *int32 // 客户端期望的申诉版本号（乐观锁），为空时不校验
+	Version         int32  // 申诉当前版本号
+	ReviewVersion   int32  // 评价当前版本号，申诉通过时需要隐藏评价
+}
+
+// FollowUpParam 用户追评的参数
+type FollowUpParam struct {
+	ReviewID  int64
+	UserID    int64
+	Content   string
+	PicInfo   string
+	VideoInfo string
+}
+
+// AuditFollowUpParam 运营审核追评的参数
+type AuditFollowUpParam struct {
+	FollowUpID int64
+	OpUser     string
+	OpReason   string
+	OpRemarks  string
+	Status     int32
+
+	ExpectedVersion *int32 // 客户端期望的追评版本号（乐观锁），为空时不校验
+	Version         int32  // 追评当前版本号
+}
+
+// DeleteReviewParam 删除评价的参数（用户删除自己的评价，或运营强制删除）
+type DeleteReviewParam struct {
 	ReviewID int64
-	AppealID int64
-	OpUser   string
-	Status   int32
+	UserID   int64  // 用户删除时必填，用于校验评价归属
+	OpUser   string // 运营强制删除时的运营者标识
+	OpReason string // 运营强制删除的原因
+
+	ExpectedVersion *int32 // 客户端期望的评价版本号（乐观锁），为空时不校验
+	Version         int32  // 评价当前版本号
+	UpdateBy        string // 更新方标识，由biz层填充
+}
+
+// UpdateReplyParam 商家修改回复的参数
+type UpdateReplyParam struct {
+	ReplyID   int64
+	StoreID   int64
+	Content   string
+	PicInfo   string
+	VideoInfo string
+	ExtJSON   string // 机审结果，由biz层填充
+
+	ExpectedVersion *int32 // 客户端期望的回复版本号（乐观锁），为空时不校验
+	Version         int32  // 回复当前版本号
+}
+
+// DeleteReplyParam 商家撤回回复的参数
+type DeleteReplyParam struct {
+	ReplyID int64
+	StoreID int64
+
+	ExpectedVersion *int32 // 客户端期望的回复版本号（乐观锁），为空时不校验
+	Version         int32  // 回复当前版本号
+}
+
+// UpdateReviewParam 用户修改评价的参数
+type UpdateReviewParam struct {
+	ReviewID     int64
+	UserID       int64
+	Score        int32
+	ServiceScore int32
+	ExpressScore int32
+	Content      string
+	PicInfo      string
+	VideoInfo    string
+	HasMedia     int32  // 由biz层填充
+	OpReason     string // 机审结果，由biz层填充
+	ExtJSON      string // 机审结果，由biz层填充
+
+	ExpectedVersion *int32 // 客户端期望的评价版本号（乐观锁），为空时不校验
+	Version         int32  // 评价当前版本号
+}
+
+// ListPendingReviewParam O端待审核评价队列的筛选条件，零值表示不过滤
+type ListPendingReviewParam struct {
+	StoreID  int64
+	Score    int32
+	HasMedia *int32    // 是否有图或视频
+	StartAt  time.Time // 评价创建时间范围
+	EndAt    time.Time
+}
+
+// ListPendingAppealParam O端待审核申诉队列的筛选条件，零值表示不过滤
+type ListPendingAppealParam struct {
+	StoreID   int64
+	Reason    string // 申诉原因类别
+	SLAStatus int32  // 审核时效状态：1未超时；2已超时；3已升级
+}
+
+// BatchAuditReviewParam 运营批量审核评价的参数，所有评价使用同一个审核结果
+type BatchAuditReviewParam struct {
+	ReviewIDs []int64
+	OpUser    string
+	OpReason  string
+	OpRemarks string
+	Status    int32
+}
+
+// BatchAuditAppealParam 运营批量审核申诉的参数，所有申诉使用同一个审核结果
+type BatchAuditAppealParam struct {
+	AppealIDs []int64
+	OpUser    string
+	OpReason  string
+	OpRemarks string
+	Status    int32
 }
@@ -0,0 +1,69 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	v1 "review-service/api/review/v1"
+	"review-service/internal/data/model"
+	"time"
+)
+
+// 回复历史的操作类型 review_reply_history.action
+const (
+	ReplyActionUpdate int32 = 1 // 修改
+	ReplyActionDelete int32 = 2 // 撤回
+)
+
+// UpdateReply 商家修改回复，修改前的内容保存到回复历史中
+func (uc *ReviewUsecase) UpdateReply(ctx context.Context, param *UpdateReplyParam) (*model.ReviewReplyInfo, error) {
+	uc.log.WithContext(ctx).Debugf("[biz] UpdateReply param:%v", param)
+	reply, err := uc.checkReplyEditable(ctx, param.ReplyID, param.StoreID, param.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+	m := uc.moderate(param.Content, false)
+	if m.blocked() {
+		return nil, v1.ErrorContentIllegal("回复%s", m.OpReason)
+	}
+	param.ExtJSON = m.ExtJSON
+	param.Version = reply.Version
+	if err := uc.repo.UpdateReply(ctx, reply, param); err != nil {
+		return nil, err
+	}
+	reply.Content = param.Content
+	reply.PicInfo = param.PicInfo
+	reply.VideoInfo = param.VideoInfo
+	reply.ExtJSON = param.ExtJSON
+	reply.Version++
+	return reply, nil
+}
+
+// DeleteReply 商家撤回回复，撤回后评价变为未回复，商家可以重新回复
+func (uc *ReviewUsecase) DeleteReply(ctx context.Context, param *DeleteReplyParam) error {
+	uc.log.WithContext(ctx).Debugf("[biz] DeleteReply param:%v", param)
+	reply, err := uc.checkReplyEditable(ctx, param.ReplyID, param.StoreID, param.ExpectedVersion)
+	if err != nil {
+		return err
+	}
+	param.Version = reply.Version
+	return uc.repo.DeleteReply(ctx, reply, param)
+}
+
+// checkReplyEditable 校验回复是否允许修改或撤回
+func (uc *ReviewUsecase) checkReplyEditable(ctx context.Context, replyID, storeID int64, expectedVersion *int32) (*model.ReviewReplyInfo, error) {
+	reply, err := uc.repo.GetReply(ctx, replyID)
+	if err != nil {
+		return nil, err
+	}
+	// 水平越权校验，商家只能修改自己店铺的回复
+	if reply.StoreID != storeID {
+		return nil, errors.New("水平越权")
+	}
+	if time.Since(reply.CreateAt) > uc.replyEditWindow {
+		return nil, v1.ErrorReplyEditExpired("回复:%d已超过可修改时间", replyID)
+	}
+	if err := CheckVersion(expectedVersion, reply.Version); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
@@ -2,9 +2,12 @@ package biz
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	v1 "review-service/api/review/v1"
+	"review-service/internal/conf"
 	"review-service/internal/data/model"
+	"review-service/pkg/sensitive"
 	"review-service/pkg/snowflake"
 	"strings"
 	"time"
@@ -14,54 +17,210 @@ import (
 
 type ReviewRepo interface {
 	SaveReview(context.Context, *model.ReviewInfo) (*model.ReviewInfo, error)
+	SaveReviews(context.Context, []*model.ReviewInfo) ([]*model.ReviewInfo, error)
 	GetReviewByOrderID(context.Context, int64) ([]*model.ReviewInfo, error)
+	GetReviewByOrderSku(ctx context.Context, orderID, skuID int64) ([]*model.ReviewInfo, error)
 	GetReview(context.Context, int64) (*model.ReviewInfo, error)
-	SaveReply(context.Context, *model.ReviewReplyInfo) (*model.ReviewReplyInfo, error)
+	SaveReply(ctx context.Context, reply *model.ReviewReplyInfo, version int32) (*model.ReviewReplyInfo, error)
 	GetReviewReply(context.Context, int64) (*model.ReviewReplyInfo, error)
+	GetReply(context.Context, int64) (*model.ReviewReplyInfo, error)
+	UpdateReply(context.Context, *model.ReviewReplyInfo, *UpdateReplyParam) error
+	DeleteReply(context.Context, *model.ReviewReplyInfo, *DeleteReplyParam) error
 	AuditReview(context.Context, *AuditParam) error
 	AppealReview(context.Context, *AppealParam) (*model.ReviewAppealInfo, error)
+	GetAppeal(context.Context, int64) (*model.ReviewAppealInfo, error)
 	AuditAppeal(context.Context, *AuditAppealParam) error
-	ListReviewByUserID(ctx context.Context, userID int64, offset, limit int) ([]*model.ReviewInfo, error)
-	ListReviewByStoreID(ctx context.Context, userID int64, offset, limit int) ([]*MyReviewInfo, error)
+	WithdrawAppeal(context.Context, *WithdrawAppealParam) error
+	ListAppealByStoreID(ctx context.Context, storeID int64, status int32, offset, limit int) ([]*model.ReviewAppealInfo, error)
+	EscalateOverdueAppeals(ctx context.Context, now time.Time, limit int) (int64, error)
+	ListOverdueAppeals(ctx context.Context, deadlineBefore time.Time, limit int) ([]*model.ReviewAppealInfo, error)
+	CloseAppeal(context.Context, *CloseAppealParam) error
+	ListEscalatedAppeals(ctx context.Context, offset, limit int) ([]*model.ReviewAppealInfo, error)
+	ListPendingReviews(ctx context.Context, param *ListPendingReviewParam, offset, limit int) ([]*model.ReviewInfo, error)
+	ListPendingAppeals(ctx context.Context, param *ListPendingAppealParam, offset, limit int) ([]*model.ReviewAppealInfo, error)
+	ListOperationLogs(context.Context, int64) ([]*model.ReviewOperationLog, error)
+	ListReviewByUserID(ctx context.Context, userID int64, token *PageToken, offset, limit int) ([]*model.ReviewInfo, error)
+	ListReviewByStoreID(ctx context.Context, storeID int64, token *PageToken, offset, limit int) ([]*MyReviewInfo, *PageToken, error)
+	SaveFollowUp(context.Context, *model.ReviewFollowUpInfo) (*model.ReviewFollowUpInfo, error)
+	GetFollowUp(context.Context, int64) (*model.ReviewFollowUpInfo, error)
+	GetFollowUpByReviewID(context.Context, int64) ([]*model.ReviewFollowUpInfo, error)
+	ListFollowUpByUserID(ctx context.Context, userID int64, offset, limit int) ([]*model.ReviewFollowUpInfo, error)
+	AuditFollowUp(context.Context, *AuditFollowUpParam) error
+	DeleteReview(context.Context, *DeleteReviewParam) error
+	UpdateReview(context.Context, *model.ReviewInfo, *UpdateReviewParam) error
+	ListReviewRevisions(context.Context, int64) ([]*model.ReviewRevision, error)
+	GetStoreSummary(context.Context, int64) (*model.ReviewStoreSummary, error)
+	LockOrder(ctx context.Context, orderID int64) (unlock func(), err error)
+	GetIdempotentReviewID(ctx context.Context, userID int64, key string) (int64, error)
+	SetIdempotentReviewID(ctx context.Context, userID int64, key string, reviewID int64) error
 }
 
 type ReviewUsecase struct {
-	repo ReviewRepo
-	log  *log.Helper
+	repo   ReviewRepo
+	order  OrderRepo
+	goods  GoodsRepo
+	filter *sensitive.Filter
+	log    *log.Helper
+
+	replyEditWindow  time.Duration // 商家回复后允许修改和撤回的时间窗口
+	reviewEditWindow time.Duration // 用户评价后允许修改评价的时间窗口
+	followUpWindow   time.Duration // 用户评价后允许追评的时间窗口
+
+	appealMaxSubmitTimes int32            // 同一条评价最多提交申诉的次数
+	appealSLA            *appealSLAPolicy // 申诉审核时效策略
+
+	defaultReviewAfter time.Duration // 订单完成超过这个时间未评价才能创建默认好评
 }
 
-func NewReviewUsecase(repo ReviewRepo, logger log.Logger) *ReviewUsecase {
+func NewReviewUsecase(repo ReviewRepo, order OrderRepo, goods GoodsRepo, filter *sensitive.Filter, cfg *conf.Review, logger log.Logger) *ReviewUsecase {
 	return &ReviewUsecase{
-		repo: repo,
-		log:  log.NewHelper(logger),
+		repo:   repo,
+		order:  order,
+		goods:  goods,
+		filter: filter,
+		log:    log.NewHelper(logger),
+
+		replyEditWindow:  cfg.GetReplyEditWindow().AsDuration(),
+		reviewEditWindow: cfg.GetReviewEditWindow().AsDuration(),
+		followUpWindow:   followUpWindow(cfg),
+
+		appealMaxSubmitTimes: appealMaxSubmitTimes(cfg),
+		appealSLA:            newAppealSLAPolicy(cfg.GetAppealSla()),
+
+		defaultReviewAfter: defaultReviewAfter(cfg),
 	}
 }
 
 // CreateReview 创建评价
 // 实现业务逻辑的地方
 // service层调用该方法
-func (uc *ReviewUsecase) CreateReview(ctx context.Context, review *model.ReviewInfo) (*model.ReviewInfo, error) {
-	uc.log.WithContext(ctx).Debugf("[biz] CreateReview, req:%v", review)
+// 客户端带了幂等键时，重试请求直接返回第一次创建的评价
+func (uc *ReviewUsecase) CreateReview(ctx context.Context, review *model.ReviewInfo, idempotencyKey string) (*model.ReviewInfo, error) {
+	uc.log.WithContext(ctx).Debugf("[biz] CreateReview, req:%v, idempotencyKey:%v", review, idempotencyKey)
+	// 0、幂等处理
+	// 0.1 同一个幂等键已经创建过评价，直接返回
+	if ret := uc.getReviewByIdempotencyKey(ctx, review.UserID, idempotencyKey); ret != nil {
+		return ret, nil
+	}
+	// 0.2 同一个订单加锁，避免并发创建；redis不可用时不加锁，由唯一索引uk_order_sku兜底
+	unlock, err := uc.repo.LockOrder(ctx, review.OrderID)
+	if v1.IsConcurrentRequest(err) {
+		return nil, err
+	}
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("[biz] CreateReview lock order:%d fail, err:%v", review.OrderID, err)
+	} else {
+		defer unlock()
+	}
 	// 1、数据校验
 	// 1.1 参数基础校验：正常来说不应该放在这一层，你在上一层或者框架层都应该能拦住（validate参数校验）
-	// 1.2 参数业务校验：带业务逻辑的参数校验，比如已经评价过的订单不能再创建评价
-	reviews, err := uc.repo.GetReviewByOrderID(ctx, review.OrderID)
+	// 1.2 参数业务校验：带业务逻辑的参数校验，比如已经评价过的订单商品不能再创建评价
+	// 一个订单可能包含多个商品，按 订单ID + skuID 判断是否已评价
+	reviews, err := uc.repo.GetReviewByOrderSku(ctx, review.OrderID, review.SkuID)
 	if err != nil {
 		return nil, v1.ErrorDbFailed("查询数据库失败")
 	}
 	if len(reviews) > 0 {
+		// 同一个幂等键的重试请求，返回原来的评价
+		if ret := findRetriedReview(reviews, review.UserID, idempotencyKey); ret != nil {
+			return ret, nil
+		}
 		// 已经评价过
-		fmt.Printf("订单已评价, len(reviews):%d\n", len(reviews))
-		return nil, v1.ErrorOrderReviewed("订单:%d已评价", review.OrderID)
+		fmt.Printf("订单商品已评价, len(reviews):%d\n", len(reviews))
+		return nil, orderReviewedError(reviews[0])
 	}
 	// 2、生成review ID
 	// 这里可以使用雪花算法自己生成
 	// 也可以直接接入公司内部的分布式ID生成服务（前提是公司内部有这种服务）
 	review.ReviewID = snowflake.GenID()
-	// 3、查询订单和商品快照信息
-	// 实际业务场景下就需要查询订单服务和商家服务（比如说通过RPC调用订单服务和商家服务）
-	// 4、拼装数据入库
-	return uc.repo.SaveReview(ctx, review)
+	// 3、内容机审：明确违规直接拒绝，干净的纯文字评价直接通过，其余转人工审核
+	uc.moderateReview(review)
+	// 4、查询订单和商品快照信息
+	// 通过RPC调用订单服务和商品服务，订单必须是当前用户的并且已完成
+	order, err := uc.checkOrder(ctx, review.OrderID, review.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.fillGoodsSnapshot(ctx, review, order); err != nil {
+		return nil, err
+	}
+	// 5、拼装数据入库
+	review.CtrlJSON = marshalReviewCtrl(&reviewCtrl{IdempotencyKey: idempotencyKey})
+	ret, err := uc.repo.SaveReview(ctx, review)
+	if v1.IsOrderReviewed(err) {
+		// 没有拿到锁时并发的重试请求被唯一索引拦住，再查一次原来的评价
+		reviews, _ := uc.repo.GetReviewByOrderSku(ctx, review.OrderID, review.SkuID)
+		if ret := findRetriedReview(reviews, review.UserID, idempotencyKey); ret != nil {
+			return ret, nil
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	// 6、记录幂等键，缓存写失败不影响结果，重试时由ctrl_json兜底
+	if len(idempotencyKey) > 0 {
+		if err := uc.repo.SetIdempotentReviewID(ctx, review.UserID, idempotencyKey, ret.ReviewID); err != nil {
+			uc.log.WithContext(ctx).Warnf("[biz] CreateReview set idempotency key fail, err:%v", err)
+		}
+	}
+	return ret, nil
+}
+
+// orderReviewedError 订单商品已经有评价时返回的错误
+// 评价删除后仍然占用这个订单商品，不能再次评价，需要和已评价区分开提示用户
+func orderReviewedError(review *model.ReviewInfo) error {
+	if review.DeleteAt.Valid {
+		return v1.ErrorOrderReviewed("订单:%d商品:%d的评价已删除，删除后不能再次评价", review.OrderID, review.SkuID)
+	}
+	return v1.ErrorOrderReviewed("订单:%d商品:%d已评价", review.OrderID, review.SkuID)
+}
+
+// BatchCreateReview 批量创建评价
+// 同一个订单下的多个商品一次性评价，所有评价在一个事务中写入
+func (uc *ReviewUsecase) BatchCreateReview(ctx context.Context, reviews []*model.ReviewInfo) ([]*model.ReviewInfo, error) {
+	uc.log.WithContext(ctx).Debugf("[biz] BatchCreateReview, len(reviews):%d", len(reviews))
+	if len(reviews) == 0 {
+		return nil, v1.ErrorInvalidParam("评价列表不能为空")
+	}
+	// 1、数据校验
+	// 1.1 批量评价必须是同一用户的同一订单，且同一个商品不能重复出现
+	orderID, userID := reviews[0].OrderID, reviews[0].UserID
+	skus := make(map[int64]struct{}, len(reviews))
+	for _, review := range reviews {
+		if review.OrderID != orderID || review.UserID != userID {
+			return nil, v1.ErrorInvalidParam("批量评价只能针对同一个订单")
+		}
+		if _, ok := skus[review.SkuID]; ok {
+			return nil, v1.ErrorInvalidParam("商品:%d重复评价", review.SkuID)
+		}
+		skus[review.SkuID] = struct{}{}
+	}
+	// 1.2 已经评价过的订单商品不能再创建评价
+	existed, err := uc.repo.GetReviewByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, v1.ErrorDbFailed("查询数据库失败")
+	}
+	for _, v := range existed {
+		if _, ok := skus[v.SkuID]; ok {
+			return nil, orderReviewedError(v)
+		}
+	}
+	// 1.3 订单必须是当前用户的并且已完成
+	order, err := uc.checkOrder(ctx, orderID, userID)
+	if err != nil {
+		return nil, err
+	}
+	// 2、生成review ID，填充商品快照，并逐条机审
+	for _, review := range reviews {
+		review.ReviewID = snowflake.GenID()
+		if err := uc.fillGoodsSnapshot(ctx, review, order); err != nil {
+			return nil, err
+		}
+		uc.moderateReview(review)
+	}
+	// 3、拼装数据入库
+	return uc.repo.SaveReviews(ctx, reviews)
 }
 
 // GetReview 根据评价ID获取评价
@@ -74,6 +233,28 @@ func (uc *ReviewUsecase) GetReview(ctx context.Context, reviewID int64) (*model.
 func (uc *ReviewUsecase) CreateReply(ctx context.Context, param *ReplyParam) (*model.ReviewReplyInfo, error) {
 	// 调用data层创建一个评价的回复
 	uc.log.WithContext(ctx).Debugf("[biz] CreateReply param:%v", param)
+	// 1. 数据校验
+	// 1.1 数据合法性校验（已回复的评价不允许商家再次回复）
+	review, err := uc.repo.GetReview(ctx, param.ReviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.HasReply == 1 {
+		return nil, v1.ErrorReviewAlreadyReplied("评价:%d已回复", param.ReviewID)
+	}
+	// 1.2 水平越权校验（A商家只能回复自己的不能回复B商家的）
+	if review.StoreID != param.StoreID {
+		return nil, errors.New("水平越权")
+	}
+	// 1.3 乐观锁校验
+	if err := CheckVersion(param.ExpectedVersion, review.Version); err != nil {
+		return nil, err
+	}
+	// 1.4 内容机审，回复没有审核流程，明确违规的直接拒绝，疑似违规的记录命中的敏感词供运营抽查
+	m := uc.moderate(param.Content, false)
+	if m.blocked() {
+		return nil, v1.ErrorContentIllegal("回复%s", m.OpReason)
+	}
 	reply := &model.ReviewReplyInfo{
 		ReplyID:   snowflake.GenID(),
 		ReviewID:  param.ReviewID,
@@ -81,30 +262,93 @@ func (uc *ReviewUsecase) CreateReply(ctx context.Context, param *ReplyParam) (*m
 		Content:   param.Content,
 		PicInfo:   param.PicInfo,
 		VideoInfo: param.VideoInfo,
+		ExtJSON:   m.ExtJSON,
 	}
-	return uc.repo.SaveReply(ctx, reply)
+	return uc.repo.SaveReply(ctx, reply, review.Version)
 }
 
 // AuditReview 审核评价
 func (uc *ReviewUsecase) AuditReview(ctx context.Context, param *AuditParam) error {
 	uc.log.WithContext(ctx).Debugf("[biz] AuditReview param:%v", param)
+	review, err := uc.repo.GetReview(ctx, param.ReviewID)
+	if err != nil {
+		return err
+	}
+	// 按状态机校验状态变更是否合法
+	if err := CheckReviewStatus(review.Status, param.Status); err != nil {
+		return err
+	}
+	if err := CheckVersion(param.ExpectedVersion, review.Version); err != nil {
+		return err
+	}
+	param.Version = review.Version
 	return uc.repo.AuditReview(ctx, param)
 }
 
 // AppealReview 申诉评价
+// 同一条评价同时只能有一个待审核的申诉，被驳回或撤回后可以重新提交，但提交次数有上限
 func (uc ReviewUsecase) AppealReview(ctx context.Context, param *AppealParam) (*model.ReviewAppealInfo, error) {
 	uc.log.WithContext(ctx).Debugf("[biz] AppealReview param:%v", param)
+	review, err := uc.repo.GetReview(ctx, param.ReviewID)
+	if err != nil {
+		return nil, err
+	}
+	// 水平越权校验，商家只能申诉自己店铺的评价
+	if review.StoreID != param.StoreID {
+		return nil, errors.New("水平越权")
+	}
+	// 申诉通过后评价会被隐藏，评价当前状态必须允许隐藏
+	if !CanAppeal(review.Status) {
+		return nil, v1.ErrorReviewStatusInvalid("评价:%d当前状态不能申诉", param.ReviewID)
+	}
+	param.MaxSubmitTimes = uc.appealMaxSubmitTimes
+	param.DeadlineAt = uc.appealSLA.deadline(param.Reason, time.Now())
 	return uc.repo.AppealReview(ctx, param)
 }
 
 // AuditAppeal 审核申诉
+// 只有申诉通过才会隐藏评价，申诉驳回时评价保持原状态
 func (uc ReviewUsecase) AuditAppeal(ctx context.Context, param *AuditAppealParam) error {
 	uc.log.WithContext(ctx).Debugf("[biz] AuditAppeal param:%v", param)
+	// 运营只能给出通过或驳回的结论，撤回是商家的操作
+	if param.Status != AppealStatusApproved && param.Status != AppealStatusRejected {
+		return v1.ErrorInvalidParam("申诉审核结果:%d不合法", param.Status)
+	}
+	appeal, err := uc.repo.GetAppeal(ctx, param.AppealID)
+	if err != nil {
+		return err
+	}
+	// 申诉、评价、店铺三者必须对应，避免审核结果作用到其他评价上
+	if appeal.ReviewID != param.ReviewID || appeal.StoreID != param.StoreID {
+		return v1.ErrorInvalidParam("申诉:%d不属于店铺:%d的评价:%d", param.AppealID, param.StoreID, param.ReviewID)
+	}
+	if err := CheckAppealStatus(appeal.Status, param.Status); err != nil {
+		return err
+	}
+	if err := CheckVersion(param.ExpectedVersion, appeal.Version); err != nil {
+		return err
+	}
+	param.Version = appeal.Version
+	review, err := uc.repo.GetReview(ctx, param.ReviewID)
+	if err != nil {
+		return err
+	}
+	if review.StoreID != appeal.StoreID {
+		return v1.ErrorInvalidParam("评价:%d不属于店铺:%d", param.ReviewID, appeal.StoreID)
+	}
+	// 申诉通过需要隐藏评价，评价状态也要符合状态机
+	if param.Status == AppealStatusApproved {
+		if err := CheckReviewStatus(review.Status, ReviewStatusHidden); err != nil {
+			return err
+		}
+		param.ReviewVersion = review.Version
+	}
 	return uc.repo.AuditAppeal(ctx, param)
 }
 
 // ListReviewByUserID 根据userID分页查询评价
-func (uc ReviewUsecase) ListReviewByUserID(ctx context.Context, userID int64, page, size int) ([]*model.ReviewInfo, error) {
+// 带了pageToken时按游标翻页，否则按page/size翻页
+func (uc ReviewUsecase) ListReviewByUserID(ctx context.Context, userID int64, page, size int, pageToken string) ([]*model.ReviewInfo, string, error) {
 	if page <= 0 {
 		page = 1
 	}
@@ -113,11 +357,26 @@ func (uc ReviewUsecase) ListReviewByUserID(ctx context.Context, userID int64, pa
 	}
 	offset := (page - 1) * size
 	limit := size
-	uc.log.WithContext(ctx).Debugf("[biz] ListReviewByUserID userID:%v", userID)
-	return uc.repo.ListReviewByUserID(ctx, userID, offset, limit)
+	token, err := DecodePageToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	uc.log.WithContext(ctx).Debugf("[biz] ListReviewByUserID userID:%v token:%v", userID, token)
+	list, err := uc.repo.ListReviewByUserID(ctx, userID, token, offset, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	// 不足一页说明已经是最后一页
+	var next *PageToken
+	if len(list) == limit {
+		next = &PageToken{LastID: list[len(list)-1].ID}
+	}
+	return list, EncodePageToken(next), nil
 }
 
-func (uc ReviewUsecase) ListReviewByStoreID(ctx context.Context, storeID int64, page, size int) ([]*MyReviewInfo, error) {
+// ListReviewByStoreID 根据storeID分页查询评价
+// 带了pageToken时按ES search_after翻页，否则按page/size翻页
+func (uc ReviewUsecase) ListReviewByStoreID(ctx context.Context, storeID int64, page, size int, pageToken string) ([]*MyReviewInfo, string, error) {
 	if page <= 0 {
 		page = 1
 	}
@@ -126,8 +385,19 @@ func (uc ReviewUsecase) ListReviewByStoreID(ctx context.Context, storeID int64,
 	}
 	offset := (page - 1) * size
 	limit := size
-	uc.log.WithContext(ctx).Debugf("[biz] ListReviewByStoreID storeID:%v", storeID)
-	return uc.repo.ListReviewByStoreID(ctx, storeID, offset, limit)
+	token, err := DecodePageToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	uc.log.WithContext(ctx).Debugf("[biz] ListReviewByStoreID storeID:%v token:%v", storeID, token)
+	list, next, err := uc.repo.ListReviewByStoreID(ctx, storeID, token, offset, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(list) < limit {
+		next = nil
+	}
+	return list, EncodePageToken(next), nil
 }
 
 // 解决es中的时间反序列化报错问题
@@ -150,6 +420,34 @@ type MyReviewInfo struct {
 	StoreID  int64      `json:"store_id"`         // 店铺id
 	UserID   int64      `json:"user_id"`          // 用户id
 
+	FollowUp *MyFollowUpInfo `json:"follow_up"` // 追评
+	Reply    *MyReplyInfo    `json:"reply"`     // 商家回复
+	Appeal   *MyAppealInfo   `json:"appeal"`    // 最新一次申诉
+}
+
+// MyFollowUpInfo es评价文档中的追评信息
+type MyFollowUpInfo struct {
+	FollowUpID int64  `json:"follow_up_id,string"` // 追评id
+	Content    string `json:"content"`             // 追评内容
+	PicInfo    string `json:"pic_info"`            // 媒体信息：图片
+	VideoInfo  string `json:"video_info"`          // 媒体信息：视频
+	Status     int32  `json:"status,string"`       // 状态
+	CreateAt   MyTime `json:"create_at"`           // 追评时间
+}
+
+// MyReplyInfo es评价文档中的商家回复
+type MyReplyInfo struct {
+	ReplyID   int64  `json:"reply_id,string"` // 回复id
+	Content   string `json:"content"`         // 回复内容
+	PicInfo   string `json:"pic_info"`        // 媒体信息：图片
+	VideoInfo string `json:"video_info"`      // 媒体信息：视频
+	CreateAt  MyTime `json:"create_at"`       // 回复时间
+}
+
+// MyAppealInfo es评价文档中的申诉信息
+type MyAppealInfo struct {
+	AppealID int64 `json:"appeal_id,string"` // 申诉id
+	Status   int32 `json:"status,string"`    // 状态:10待审核；20申诉通过；30申诉驳回；40商家撤回；50超时关闭
 }
 
 type MyTime time.Time
@@ -0,0 +1,83 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	v1 "review-service/api/review/v1"
+	"review-service/internal/data/model"
+	"time"
+)
+
+// UpdateReview 用户修改评价
+// 修改前的内容保存到评价修改历史中，修改后的评价重新进入待审核状态
+func (uc *ReviewUsecase) UpdateReview(ctx context.Context, param *UpdateReviewParam) (*model.ReviewInfo, error) {
+	uc.log.WithContext(ctx).Debugf("[biz] UpdateReview param:%v", param)
+	// 1、数据校验
+	review, err := uc.repo.GetReview(ctx, param.ReviewID)
+	if err != nil {
+		return nil, err
+	}
+	// 1.1 水平越权校验，用户只能修改自己的评价
+	if review.UserID != param.UserID {
+		return nil, errors.New("水平越权")
+	}
+	// 1.2 审核不通过和已隐藏的评价不能修改
+	if !CanEditReview(review.Status) {
+		return nil, v1.ErrorReviewStatusInvalid("评价:%d当前状态不能修改", param.ReviewID)
+	}
+	// 1.3 超过修改时间窗口
+	if time.Since(review.CreateAt) > uc.reviewEditWindow {
+		return nil, v1.ErrorReviewEditExpired("评价:%d已超过可修改时间", param.ReviewID)
+	}
+	if err := CheckVersion(param.ExpectedVersion, review.Version); err != nil {
+		return nil, err
+	}
+	// 1.4 评分和创建评价一样只能是1-5，修改后的评分会重新计入店铺评分汇总
+	if !validScore(param.Score) || !validScore(param.ServiceScore) || !validScore(param.ExpressScore) {
+		return nil, v1.ErrorInvalidParam("评分只能是1-5")
+	}
+	// 2、内容机审，明确违规的修改直接拒绝，其余都需要重新人工审核
+	if len(param.PicInfo) > 0 || len(param.VideoInfo) > 0 {
+		param.HasMedia = 1
+	}
+	m := uc.moderate(param.Content, param.HasMedia == 1)
+	if m.blocked() {
+		return nil, v1.ErrorContentIllegal("评价%s", m.OpReason)
+	}
+	param.OpReason = m.OpReason
+	param.ExtJSON = m.ExtJSON
+	param.Version = review.Version
+	// 3、保存修改历史并更新评价
+	if err := uc.repo.UpdateReview(ctx, review, param); err != nil {
+		return nil, err
+	}
+	review.Score = param.Score
+	review.ServiceScore = param.ServiceScore
+	review.ExpressScore = param.ExpressScore
+	review.Content = param.Content
+	review.PicInfo = param.PicInfo
+	review.VideoInfo = param.VideoInfo
+	review.HasMedia = param.HasMedia
+	review.Status = ReviewStatusPending
+	review.Version++
+	return review, nil
+}
+
+// ListReviewRevisions 查询评价的修改历史，按修改先后顺序返回
+func (uc *ReviewUsecase) ListReviewRevisions(ctx context.Context, reviewID int64) (*model.ReviewInfo, []*model.ReviewRevision, error) {
+	uc.log.WithContext(ctx).Debugf("[biz] ListReviewRevisions reviewID:%v", reviewID)
+	review, err := uc.repo.GetReview(ctx, reviewID)
+	if err != nil {
+		return nil, nil, err
+	}
+	revisions, err := uc.repo.ListReviewRevisions(ctx, reviewID)
+	if err != nil {
+		return nil, nil, v1.ErrorDbFailed("查询数据库失败")
+	}
+	return review, revisions, nil
+}
+
+// validScore 评分只能是1-5
+func validScore(score int32) bool {
+	return score >= 1 && score <= 5
+}
@@ -0,0 +1,88 @@
+package biz
+
+import (
+	v1 "review-service/api/review/v1"
+)
+
+// 评价状态 review_info.status
+const (
+	ReviewStatusPending  int32 = 10 // 待审核
+	ReviewStatusApproved int32 = 20 // 审核通过
+	ReviewStatusRejected int32 = 30 // 审核不通过
+	ReviewStatusHidden   int32 = 40 // 隐藏
+)
+
+// 申诉状态 review_appeal_info.status
+const (
+	AppealStatusPending   int32 = 10 // 待审核
+	AppealStatusApproved  int32 = 20 // 申诉通过
+	AppealStatusRejected  int32 = 30 // 申诉驳回
+	AppealStatusWithdrawn int32 = 40 // 商家撤回
+	AppealStatusClosed    int32 = 50 // 超时关闭
+)
+
+// reviewStatusTransitions 评价状态机，key为当前状态，value为允许变更到的状态
+// 审核不通过和隐藏都是终态，不能再被审核
+var reviewStatusTransitions = map[int32]map[int32]bool{
+	ReviewStatusPending: {
+		ReviewStatusApproved: true,
+		ReviewStatusRejected: true,
+		ReviewStatusHidden:   true, // 申诉通过隐藏
+	},
+	ReviewStatusApproved: {
+		ReviewStatusHidden: true, // 申诉通过隐藏
+	},
+	ReviewStatusRejected: {},
+	ReviewStatusHidden:   {},
+}
+
+// appealStatusTransitions 申诉状态机，申诉只能从待审核变为通过、驳回、撤回或超时关闭
+// 待审核之外的申诉不会再变化，商家重新提交时会生成一条新的申诉
+var appealStatusTransitions = map[int32]map[int32]bool{
+	AppealStatusPending: {
+		AppealStatusApproved:  true,
+		AppealStatusRejected:  true,
+		AppealStatusWithdrawn: true,
+		AppealStatusClosed:    true,
+	},
+	AppealStatusApproved:  {},
+	AppealStatusRejected:  {},
+	AppealStatusWithdrawn: {},
+	AppealStatusClosed:    {},
+}
+
+// CheckReviewStatus 校验评价（追评）状态变更是否合法
+func CheckReviewStatus(from, to int32) error {
+	if !reviewStatusTransitions[from][to] {
+		return v1.ErrorReviewStatusInvalid("评价状态不能从%d变更为%d", from, to)
+	}
+	return nil
+}
+
+// CheckAppealStatus 校验申诉状态变更是否合法
+func CheckAppealStatus(from, to int32) error {
+	if !appealStatusTransitions[from][to] {
+		return v1.ErrorAppealStatusInvalid("申诉状态不能从%d变更为%d", from, to)
+	}
+	return nil
+}
+
+// CanAppeal 评价当前状态是否允许商家申诉（已隐藏和审核不通过的评价无需申诉）
+func CanAppeal(reviewStatus int32) bool {
+	return reviewStatusTransitions[reviewStatus][ReviewStatusHidden]
+}
+
+// CanEditReview 评价当前状态是否允许用户修改，修改后评价重新进入待审核状态
+// 审核不通过和已隐藏都是终态，不能通过修改绕过审核结果
+func CanEditReview(reviewStatus int32) bool {
+	return reviewStatus == ReviewStatusPending || reviewStatus == ReviewStatusApproved
+}
+
+// CheckVersion 乐观锁校验，客户端带了期望版本号时必须和当前版本号一致
+// 版本冲突是可重试的错误，客户端需要重新查询后再提交
+func CheckVersion(expected *int32, current int32) error {
+	if expected != nil && *expected != current {
+		return v1.ErrorVersionConflict("数据已被修改，期望版本:%d，当前版本:%d", *expected, current)
+	}
+	return nil
+}
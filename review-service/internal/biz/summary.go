@@ -0,0 +1,42 @@
+package biz
+
+import (
+	"context"
+)
+
+// StoreSummary 店铺评分汇总，只统计审核通过的评价
+type StoreSummary struct {
+	StoreID         int64
+	ReviewCount     int32
+	AvgScore        float64  // 平均评分
+	AvgServiceScore float64  // 商家服务平均评分
+	AvgExpressScore float64  // 物流平均评分
+	StarCounts      [5]int32 // 1~5星评价数，下标0为1星
+	MediaRatio      float64  // 有图或视频的评价占比
+	ReplyRatio      float64  // 商家已回复的评价占比
+}
+
+// GetStoreSummary 查询店铺评分汇总
+// 汇总数据在审核、申诉、回复、删除时增量维护，这里只做计算
+func (uc *ReviewUsecase) GetStoreSummary(ctx context.Context, storeID int64) (*StoreSummary, error) {
+	uc.log.WithContext(ctx).Debugf("[biz] GetStoreSummary storeID:%v", storeID)
+	s, err := uc.repo.GetStoreSummary(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+	summary := &StoreSummary{
+		StoreID:     storeID,
+		ReviewCount: s.ReviewCount,
+		StarCounts:  [5]int32{s.Star1Count, s.Star2Count, s.Star3Count, s.Star4Count, s.Star5Count},
+	}
+	if s.ReviewCount <= 0 {
+		return summary, nil
+	}
+	count := float64(s.ReviewCount)
+	summary.AvgScore = float64(s.ScoreSum) / count
+	summary.AvgServiceScore = float64(s.ServiceScoreSum) / count
+	summary.AvgExpressScore = float64(s.ExpressScoreSum) / count
+	summary.MediaRatio = float64(s.MediaCount) / count
+	summary.ReplyRatio = float64(s.ReplyCount) / count
+	return summary, nil
+}
@@ -0,0 +1,58 @@
+package data
+
+import (
+	"context"
+	v1 "review-service/api/review/v1"
+	"review-service/internal/biz"
+	"review-service/internal/data/model"
+	"review-service/internal/data/query"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// WithdrawAppeal 商家撤回申诉
+func (r *reviewRepo) WithdrawAppeal(ctx context.Context, param *biz.WithdrawAppealParam) error {
+	return r.data.query.Transaction(func(tx *query.Query) error {
+		info, err := tx.ReviewAppealInfo.
+			WithContext(ctx).
+			Where(
+				tx.ReviewAppealInfo.AppealID.Eq(param.AppealID),
+				tx.ReviewAppealInfo.Version.Eq(param.Version),
+			).
+			Updates(map[string]interface{}{
+				"status":  biz.AppealStatusWithdrawn,
+				"version": gorm.Expr("version + 1"),
+			})
+		if err != nil {
+			return err
+		}
+		if info.RowsAffected == 0 {
+			return v1.ErrorVersionConflict("申诉:%d已被修改，请重试", param.AppealID)
+		}
+		return writeOperationLog(ctx, tx, &model.ReviewOperationLog{
+			ReviewID:     param.ReviewID,
+			TargetType:   biz.OpTargetAppeal,
+			TargetID:     param.AppealID,
+			Action:       biz.OpActionWithdraw,
+			Actor:        strconv.FormatInt(param.StoreID, 10),
+			Role:         biz.OpRoleMerchant,
+			BeforeStatus: biz.AppealStatusPending,
+			AfterStatus:  biz.AppealStatusWithdrawn,
+		})
+	})
+}
+
+// ListAppealByStoreID 根据storeID分页查询申诉，status为0时不按状态过滤
+func (r *reviewRepo) ListAppealByStoreID(ctx context.Context, storeID int64, status int32, offset, limit int) ([]*model.ReviewAppealInfo, error) {
+	q := r.data.query.ReviewAppealInfo.
+		WithContext(ctx).
+		Where(r.data.query.ReviewAppealInfo.StoreID.Eq(storeID))
+	if status > 0 {
+		q = q.Where(r.data.query.ReviewAppealInfo.Status.Eq(status))
+	}
+	return q.Order(r.data.query.ReviewAppealInfo.ID.Desc()).
+		Limit(limit).
+		Offset(offset).
+		Find()
+}
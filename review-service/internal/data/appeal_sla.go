@@ -0,0 +1,132 @@
+package data
+
+import (
+	"context"
+	v1 "review-service/api/review/v1"
+	"review-service/internal/biz"
+	"review-service/internal/data/model"
+	"review-service/internal/data/query"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EscalateOverdueAppeals 把超过审核截止时间的待审核申诉升级到高级审核队列，返回升级的数量
+// 先锁住要升级的申诉再逐条记录操作日志，避免多个实例同时扫描时重复升级
+func (r *reviewRepo) EscalateOverdueAppeals(ctx context.Context, now time.Time, limit int) (int64, error) {
+	var escalated int64
+	err := r.data.query.Transaction(func(tx *query.Query) error {
+		appeals, err := tx.ReviewAppealInfo.
+			WithContext(ctx).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(
+				tx.ReviewAppealInfo.Status.Eq(biz.AppealStatusPending),
+				tx.ReviewAppealInfo.DeadlineAt.Lt(now),
+				tx.ReviewAppealInfo.EscalateAt.IsNull(),
+			).
+			Order(tx.ReviewAppealInfo.DeadlineAt).
+			Limit(limit).
+			Find()
+		if err != nil || len(appeals) == 0 {
+			return err
+		}
+		appealIDs := make([]int64, 0, len(appeals))
+		for _, appeal := range appeals {
+			appealIDs = append(appealIDs, appeal.AppealID)
+		}
+		info, err := tx.ReviewAppealInfo.
+			WithContext(ctx).
+			Where(tx.ReviewAppealInfo.AppealID.In(appealIDs...)).
+			Updates(map[string]interface{}{
+				"escalate_at": now,
+				"version":     gorm.Expr("version + 1"),
+			})
+		if err != nil {
+			return err
+		}
+		escalated = info.RowsAffected
+		for _, appeal := range appeals {
+			if err := writeOperationLog(ctx, tx, &model.ReviewOperationLog{
+				ReviewID:     appeal.ReviewID,
+				TargetType:   biz.OpTargetAppeal,
+				TargetID:     appeal.AppealID,
+				Action:       biz.OpActionEscalate,
+				Actor:        biz.OpActorSystem,
+				Role:         biz.OpRoleSystem,
+				BeforeStatus: appeal.Status,
+				AfterStatus:  appeal.Status,
+				Reason:       "超过审核截止时间，升级到高级审核队列",
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return escalated, nil
+}
+
+// ListOverdueAppeals 查询审核截止时间早于deadlineBefore的待审核申诉
+func (r *reviewRepo) ListOverdueAppeals(ctx context.Context, deadlineBefore time.Time, limit int) ([]*model.ReviewAppealInfo, error) {
+	return r.data.query.ReviewAppealInfo.
+		WithContext(ctx).
+		Where(
+			r.data.query.ReviewAppealInfo.Status.Eq(biz.AppealStatusPending),
+			r.data.query.ReviewAppealInfo.DeadlineAt.Lt(deadlineBefore),
+		).
+		Order(r.data.query.ReviewAppealInfo.DeadlineAt).
+		Limit(limit).
+		Find()
+}
+
+// CloseAppeal 超时关闭申诉，评价保持原状态
+func (r *reviewRepo) CloseAppeal(ctx context.Context, param *biz.CloseAppealParam) error {
+	return r.data.query.Transaction(func(tx *query.Query) error {
+		info, err := tx.ReviewAppealInfo.
+			WithContext(ctx).
+			Where(
+				tx.ReviewAppealInfo.AppealID.Eq(param.AppealID),
+				tx.ReviewAppealInfo.Version.Eq(param.Version),
+			).
+			Updates(map[string]interface{}{
+				"status":    biz.AppealStatusClosed,
+				"op_user":   param.OpUser,
+				"op_reason": param.OpReason,
+				"version":   gorm.Expr("version + 1"),
+			})
+		if err != nil {
+			return err
+		}
+		if info.RowsAffected == 0 {
+			return v1.ErrorVersionConflict("申诉:%d已被修改，请重试", param.AppealID)
+		}
+		return writeOperationLog(ctx, tx, &model.ReviewOperationLog{
+			ReviewID:     param.ReviewID,
+			TargetType:   biz.OpTargetAppeal,
+			TargetID:     param.AppealID,
+			Action:       biz.OpActionClose,
+			Actor:        param.OpUser,
+			Role:         biz.OpRoleSystem,
+			BeforeStatus: biz.AppealStatusPending,
+			AfterStatus:  biz.AppealStatusClosed,
+			Reason:       param.OpReason,
+		})
+	})
+}
+
+// ListEscalatedAppeals 分页查询已升级到高级审核队列的待审核申诉
+func (r *reviewRepo) ListEscalatedAppeals(ctx context.Context, offset, limit int) ([]*model.ReviewAppealInfo, error) {
+	return r.data.query.ReviewAppealInfo.
+		WithContext(ctx).
+		Where(
+			r.data.query.ReviewAppealInfo.Status.Eq(biz.AppealStatusPending),
+			r.data.query.ReviewAppealInfo.EscalateAt.IsNotNull(),
+		).
+		Order(r.data.query.ReviewAppealInfo.DeadlineAt).
+		Limit(limit).
+		Offset(offset).
+		Find()
+}
@@ -0,0 +1,60 @@
+package data
+
+import (
+	"context"
+	"review-service/internal/biz"
+	"review-service/internal/data/model"
+	"time"
+)
+
+// ListPendingReviews 按筛选条件分页查询待审核的评价，按创建时间先后排序
+func (r *reviewRepo) ListPendingReviews(ctx context.Context, param *biz.ListPendingReviewParam, offset, limit int) ([]*model.ReviewInfo, error) {
+	q := r.data.query.ReviewInfo.
+		WithContext(ctx).
+		Where(r.data.query.ReviewInfo.Status.Eq(biz.ReviewStatusPending))
+	if param.StoreID > 0 {
+		q = q.Where(r.data.query.ReviewInfo.StoreID.Eq(param.StoreID))
+	}
+	if param.Score > 0 {
+		q = q.Where(r.data.query.ReviewInfo.Score.Eq(param.Score))
+	}
+	if param.HasMedia != nil {
+		q = q.Where(r.data.query.ReviewInfo.HasMedia.Eq(*param.HasMedia))
+	}
+	if !param.StartAt.IsZero() {
+		q = q.Where(r.data.query.ReviewInfo.CreateAt.Gte(param.StartAt))
+	}
+	if !param.EndAt.IsZero() {
+		q = q.Where(r.data.query.ReviewInfo.CreateAt.Lt(param.EndAt))
+	}
+	return q.Order(r.data.query.ReviewInfo.CreateAt, r.data.query.ReviewInfo.ID).
+		Limit(limit).
+		Offset(offset).
+		Find()
+}
+
+// ListPendingAppeals 按筛选条件分页查询待审核的申诉，按审核截止时间先后排序
+func (r *reviewRepo) ListPendingAppeals(ctx context.Context, param *biz.ListPendingAppealParam, offset, limit int) ([]*model.ReviewAppealInfo, error) {
+	q := r.data.query.ReviewAppealInfo.
+		WithContext(ctx).
+		Where(r.data.query.ReviewAppealInfo.Status.Eq(biz.AppealStatusPending))
+	if param.StoreID > 0 {
+		q = q.Where(r.data.query.ReviewAppealInfo.StoreID.Eq(param.StoreID))
+	}
+	if len(param.Reason) > 0 {
+		q = q.Where(r.data.query.ReviewAppealInfo.Reason.Eq(param.Reason))
+	}
+	// 时效状态和biz.AppealSLAStatus的计算规则保持一致
+	switch param.SLAStatus {
+	case biz.AppealSLANormal:
+		q = q.Where(r.data.query.ReviewAppealInfo.EscalateAt.IsNull(), r.data.query.ReviewAppealInfo.DeadlineAt.Gte(time.Now()))
+	case biz.AppealSLAOverdue:
+		q = q.Where(r.data.query.ReviewAppealInfo.EscalateAt.IsNull(), r.data.query.ReviewAppealInfo.DeadlineAt.Lt(time.Now()))
+	case biz.AppealSLAEscalated:
+		q = q.Where(r.data.query.ReviewAppealInfo.EscalateAt.IsNotNull())
+	}
+	return q.Order(r.data.query.ReviewAppealInfo.DeadlineAt, r.data.query.ReviewAppealInfo.ID).
+		Limit(limit).
+		Offset(offset).
+		Find()
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/go-kratos/kratos/v2/log"
+	mysqlDriver "github.com/go-sql-driver/mysql"
 	"github.com/google/wire"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/driver/mysql"
@@ -12,12 +13,14 @@ import (
 	"gorm.io/gorm"
 	"review-service/internal/conf"
 	"review-service/internal/data/query"
+	"review-service/pkg/sensitive"
 	"strings"
 )
 
 // ProviderSet is data providers.
 // var ProviderSet = wire.NewSet(NewData, NewGreeterRepo, NewReviewRepo, NewDB)
-var ProviderSet = wire.NewSet(NewData, NewReviewRepo, NewDB, NewESClient, NewRedisClient)
+var ProviderSet = wire.NewSet(NewData, NewReviewRepo, NewDB, NewESClient, NewRedisClient, NewSensitiveFilter,
+	NewDiscovery, NewOrderServiceClient, NewGoodsServiceClient, NewOrderRepo, NewGoodsRepo)
 
 // Data .
 type Data struct {
@@ -58,6 +61,15 @@ func NewESClient(cfg *conf.Elasticsearch) (*elasticsearch.TypedClient, error) {
 	return elasticsearch.NewTypedClient(escfg)
 }
 
+// NewSensitiveFilter 创建敏感词过滤器，词库文件变更后自动重新加载，不需要重启服务
+func NewSensitiveFilter(cfg *conf.Sensitive) (*sensitive.Filter, func(), error) {
+	filter, err := sensitive.NewFilter(cfg.Path, cfg.ReloadInterval.AsDuration())
+	if err != nil {
+		return nil, nil, err
+	}
+	return filter, filter.Close, nil
+}
+
 func NewDB(c *conf.Data) (*gorm.DB, error) {
 	if c == nil {
 		panic(errors.New("GET:connectDB fail"))
@@ -79,3 +91,9 @@ func NewDB(c *conf.Data) (*gorm.DB, error) {
 	}
 	panic(errors.New("NewDB:connectDB fail unsupported db driver: " + c.Database.Driver))
 }
+
+// isDuplicateEntry 判断是否违反了唯一索引（MySQL错误码1062）
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *mysqlDriver.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1062
+}
@@ -0,0 +1,143 @@
+package data
+
+import (
+	"context"
+	v1 "review-service/api/review/v1"
+	"review-service/internal/biz"
+	"review-service/internal/data/model"
+	"review-service/internal/data/query"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DeleteReview 逻辑删除评价，评价下的回复、申诉和追评在同一个事务里一起删除
+func (r *reviewRepo) DeleteReview(ctx context.Context, param *biz.DeleteReviewParam) error {
+	return r.data.query.Transaction(func(tx *query.Query) error {
+		updates := map[string]interface{}{
+			"delete_at": time.Now(),
+			"update_by": param.UpdateBy,
+			"version":   gorm.Expr("version + 1"),
+		}
+		// 运营强制删除需要记录操作人和原因
+		if len(param.OpUser) > 0 {
+			updates["op_user"] = param.OpUser
+			updates["op_reason"] = param.OpReason
+		}
+		review, err := getReviewInTx(ctx, tx, param.ReviewID)
+		if err != nil {
+			return err
+		}
+		info, err := tx.ReviewInfo.
+			WithContext(ctx).
+			Where(
+				tx.ReviewInfo.ReviewID.Eq(param.ReviewID),
+				tx.ReviewInfo.Version.Eq(param.Version),
+			).
+			Updates(updates)
+		if err != nil {
+			return err
+		}
+		if info.RowsAffected == 0 {
+			return v1.ErrorVersionConflict("评价:%d已被修改，请重试", param.ReviewID)
+		}
+		// 用户删除记录用户，运营强制删除记录运营者；逻辑删除不改变评价状态
+		actor, role := param.UpdateBy, biz.OpRoleUser
+		if len(param.OpUser) > 0 {
+			actor, role = param.OpUser, biz.OpRoleOperator
+		}
+		if err := writeOperationLog(ctx, tx, &model.ReviewOperationLog{
+			ReviewID:     param.ReviewID,
+			TargetType:   biz.OpTargetReview,
+			TargetID:     param.ReviewID,
+			Action:       biz.OpActionDelete,
+			Actor:        actor,
+			Role:         role,
+			BeforeStatus: review.Status,
+			AfterStatus:  review.Status,
+			Reason:       param.OpReason,
+		}); err != nil {
+			return err
+		}
+		// 删除审核通过的评价，需要从店铺评分汇总中扣除
+		if review.Status == biz.ReviewStatusApproved {
+			if err := changeStoreSummary(ctx, tx, review, -1); err != nil {
+				return err
+			}
+		}
+		// 级联删除的回复、申诉和追评每条都记录操作日志，操作人和删除评价的一致
+		// delete_at是gorm.DeletedAt类型，Delete为逻辑删除
+		cascade := &model.ReviewOperationLog{
+			ReviewID: param.ReviewID,
+			Action:   biz.OpActionDelete,
+			Actor:    actor,
+			Role:     role,
+			Reason:   param.OpReason,
+		}
+		replies, err := tx.ReviewReplyInfo.
+			WithContext(ctx).
+			Where(tx.ReviewReplyInfo.ReviewID.Eq(param.ReviewID)).
+			Find()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ReviewReplyInfo.
+			WithContext(ctx).
+			Where(tx.ReviewReplyInfo.ReviewID.Eq(param.ReviewID)).
+			Delete(); err != nil {
+			return err
+		}
+		for _, reply := range replies {
+			if err := writeCascadeLog(ctx, tx, cascade, biz.OpTargetReply, reply.ReplyID, 0); err != nil {
+				return err
+			}
+		}
+		appeals, err := tx.ReviewAppealInfo.
+			WithContext(ctx).
+			Where(tx.ReviewAppealInfo.ReviewID.Eq(param.ReviewID)).
+			Find()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ReviewAppealInfo.
+			WithContext(ctx).
+			Where(tx.ReviewAppealInfo.ReviewID.Eq(param.ReviewID)).
+			Delete(); err != nil {
+			return err
+		}
+		for _, appeal := range appeals {
+			if err := writeCascadeLog(ctx, tx, cascade, biz.OpTargetAppeal, appeal.AppealID, appeal.Status); err != nil {
+				return err
+			}
+		}
+		followUps, err := tx.ReviewFollowUpInfo.
+			WithContext(ctx).
+			Where(tx.ReviewFollowUpInfo.ReviewID.Eq(param.ReviewID)).
+			Find()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ReviewFollowUpInfo.
+			WithContext(ctx).
+			Where(tx.ReviewFollowUpInfo.ReviewID.Eq(param.ReviewID)).
+			Delete(); err != nil {
+			return err
+		}
+		for _, followUp := range followUps {
+			if err := writeCascadeLog(ctx, tx, cascade, biz.OpTargetFollowUp, followUp.FollowUpID, followUp.Status); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// writeCascadeLog 记录随评价一起删除的回复、申诉或追评的操作日志，逻辑删除不改变状态
+func writeCascadeLog(ctx context.Context, tx *query.Query, base *model.ReviewOperationLog, targetType int32, targetID int64, status int32) error {
+	l := *base
+	l.TargetType = targetType
+	l.TargetID = targetID
+	l.BeforeStatus = status
+	l.AfterStatus = status
+	return writeOperationLog(ctx, tx, &l)
+}
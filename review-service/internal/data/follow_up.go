@@ -0,0 +1,108 @@
+package data
+
+import (
+	"context"
+	v1 "review-service/api/review/v1"
+	"review-service/internal/biz"
+	"review-service/internal/data/model"
+	"review-service/internal/data/query"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// SaveFollowUp 保存追评
+func (r *reviewRepo) SaveFollowUp(ctx context.Context, followUp *model.ReviewFollowUpInfo) (*model.ReviewFollowUpInfo, error) {
+	err := r.data.query.Transaction(func(tx *query.Query) error {
+		// 不能用Save，并发追评时由唯一索引uk_review_id兜底
+		if err := tx.ReviewFollowUpInfo.
+			WithContext(ctx).
+			Create(followUp); err != nil {
+			if isDuplicateEntry(err) {
+				return v1.ErrorFollowUpNotAllowed("评价:%d已追评", followUp.ReviewID)
+			}
+			return err
+		}
+		return writeOperationLog(ctx, tx, &model.ReviewOperationLog{
+			ReviewID:    followUp.ReviewID,
+			TargetType:  biz.OpTargetFollowUp,
+			TargetID:    followUp.FollowUpID,
+			Action:      biz.OpActionCreate,
+			Actor:       strconv.FormatInt(followUp.UserID, 10),
+			Role:        biz.OpRoleUser,
+			AfterStatus: followUp.Status,
+		})
+	})
+	return followUp, err
+}
+
+// GetFollowUp 根据追评ID查询追评
+func (r *reviewRepo) GetFollowUp(ctx context.Context, followUpID int64) (*model.ReviewFollowUpInfo, error) {
+	return r.data.query.ReviewFollowUpInfo.
+		WithContext(ctx).
+		Where(r.data.query.ReviewFollowUpInfo.FollowUpID.Eq(followUpID)).
+		First()
+}
+
+// GetFollowUpByReviewID 根据评价ID查询追评
+func (r *reviewRepo) GetFollowUpByReviewID(ctx context.Context, reviewID int64) ([]*model.ReviewFollowUpInfo, error) {
+	return r.data.query.ReviewFollowUpInfo.
+		WithContext(ctx).
+		Where(r.data.query.ReviewFollowUpInfo.ReviewID.Eq(reviewID)).
+		Find()
+}
+
+// ListFollowUpByUserID 根据userID分页查询追评
+func (r *reviewRepo) ListFollowUpByUserID(ctx context.Context, userID int64, offset, limit int) ([]*model.ReviewFollowUpInfo, error) {
+	return r.data.query.ReviewFollowUpInfo.
+		WithContext(ctx).
+		Where(r.data.query.ReviewFollowUpInfo.UserID.Eq(userID)).
+		Order(r.data.query.ReviewFollowUpInfo.ID.Desc()).
+		Limit(limit).
+		Offset(offset).
+		Find()
+}
+
+// AuditFollowUp 审核追评（运营对用户的追评进行审核）
+func (r *reviewRepo) AuditFollowUp(ctx context.Context, param *biz.AuditFollowUpParam) error {
+	return r.data.query.Transaction(func(tx *query.Query) error {
+		followUp, err := tx.ReviewFollowUpInfo.
+			WithContext(ctx).
+			Where(tx.ReviewFollowUpInfo.FollowUpID.Eq(param.FollowUpID)).
+			First()
+		if err != nil {
+			return err
+		}
+		info, err := tx.ReviewFollowUpInfo.
+			WithContext(ctx).
+			Where(
+				tx.ReviewFollowUpInfo.FollowUpID.Eq(param.FollowUpID),
+				tx.ReviewFollowUpInfo.Version.Eq(param.Version),
+			).
+			Updates(map[string]interface{}{
+				"status":     param.Status,
+				"op_user":    param.OpUser,
+				"op_reason":  param.OpReason,
+				"op_remarks": param.OpRemarks,
+				"version":    gorm.Expr("version + 1"),
+			})
+		if err != nil {
+			return err
+		}
+		if info.RowsAffected == 0 {
+			return v1.ErrorVersionConflict("追评:%d已被修改，请重试", param.FollowUpID)
+		}
+		return writeOperationLog(ctx, tx, &model.ReviewOperationLog{
+			ReviewID:     followUp.ReviewID,
+			TargetType:   biz.OpTargetFollowUp,
+			TargetID:     param.FollowUpID,
+			Action:       biz.OpActionAudit,
+			Actor:        param.OpUser,
+			Role:         biz.OpRoleOperator,
+			BeforeStatus: followUp.Status,
+			AfterStatus:  param.Status,
+			Reason:       param.OpReason,
+			Remarks:      param.OpRemarks,
+		})
+	})
+}
@@ -0,0 +1,61 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	v1 "review-service/api/review/v1"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	orderLockTTL      = 5 * time.Second // 创建评价时订单锁的过期时间
+	idempotencyKeyTTL = 24 * time.Hour  // 幂等键的有效期
+)
+
+// unlockScript 只删除自己加的锁，避免锁过期后误删别人的锁
+var unlockScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0
+`)
+
+// LockOrder 创建评价时对订单加锁
+func (r *reviewRepo) LockOrder(ctx context.Context, orderID int64) (func(), error) {
+	key := fmt.Sprintf("review:lock:order:%d", orderID)
+	token := strconv.FormatInt(time.Now().UnixNano(), 10)
+	ok, err := r.data.rdb.SetNX(ctx, key, token, orderLockTTL).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, v1.ErrorConcurrentRequest("订单:%d正在评价中，请稍后重试", orderID)
+	}
+	return func() {
+		if err := unlockScript.Run(context.Background(), r.data.rdb, []string{key}, token).Err(); err != nil {
+			r.log.Errorf("unlock order:%d fail, err:%v", orderID, err)
+		}
+	}, nil
+}
+
+func idempotencyKey(userID int64, key string) string {
+	return fmt.Sprintf("review:idempotency:%d:%s", userID, key)
+}
+
+// GetIdempotentReviewID 查询幂等键对应的评价ID，不存在时返回0
+func (r *reviewRepo) GetIdempotentReviewID(ctx context.Context, userID int64, key string) (int64, error) {
+	reviewID, err := r.data.rdb.Get(ctx, idempotencyKey(userID, key)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return reviewID, err
+}
+
+// SetIdempotentReviewID 记录幂等键对应的评价ID
+func (r *reviewRepo) SetIdempotentReviewID(ctx context.Context, userID int64, key string, reviewID int64) error {
+	return r.data.rdb.Set(ctx, idempotencyKey(userID, key), reviewID, idempotencyKeyTTL).Err()
+}
@@ -0,0 +1,71 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"review-service/internal/data/model"
+	"review-service/internal/data/query"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/middleware/tracing"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/grpc/peer"
+)
+
+// requestMeta 操作日志中记录的请求元数据
+type requestMeta struct {
+	Operation string `json:"operation,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	ClientIP  string `json:"client_ip,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+}
+
+// writeOperationLog 追加一条操作日志，必须和状态变更在同一个事务中调用
+// 操作日志只追加不修改，运营审核覆盖op_user/op_reason后仍能查到完整的操作记录
+func writeOperationLog(ctx context.Context, tx *query.Query, l *model.ReviewOperationLog) error {
+	if traceID, ok := tracing.TraceID()(ctx).(string); ok {
+		l.TraceID = traceID
+	}
+	if meta := getRequestMeta(ctx); meta != nil {
+		b, _ := json.Marshal(meta)
+		l.MetaJSON = string(b)
+	}
+	return tx.ReviewOperationLog.
+		WithContext(ctx).
+		Create(l)
+}
+
+// getRequestMeta 从kratos的transport中取出请求元数据，定时任务等没有请求的场景返回nil
+func getRequestMeta(ctx context.Context) *requestMeta {
+	tr, ok := transport.FromServerContext(ctx)
+	if !ok {
+		return nil
+	}
+	meta := &requestMeta{
+		Operation: tr.Operation(),
+		RequestID: tr.RequestHeader().Get("X-Request-Id"),
+		UserAgent: tr.RequestHeader().Get("User-Agent"),
+	}
+	// 经过网关时优先取网关透传的客户端ip
+	if ip := tr.RequestHeader().Get("X-Forwarded-For"); len(ip) > 0 {
+		meta.ClientIP = strings.TrimSpace(strings.Split(ip, ",")[0])
+	} else if ip := tr.RequestHeader().Get("X-Real-IP"); len(ip) > 0 {
+		meta.ClientIP = ip
+	} else if req, ok := http.RequestFromServerContext(ctx); ok {
+		meta.ClientIP, _, _ = net.SplitHostPort(req.RemoteAddr)
+	} else if p, ok := peer.FromContext(ctx); ok {
+		meta.ClientIP, _, _ = net.SplitHostPort(p.Addr.String())
+	}
+	return meta
+}
+
+// ListOperationLogs 查询评价的全部操作日志，按写入顺序排序
+func (r *reviewRepo) ListOperationLogs(ctx context.Context, reviewID int64) ([]*model.ReviewOperationLog, error) {
+	return r.data.query.ReviewOperationLog.
+		WithContext(ctx).
+		Where(r.data.query.ReviewOperationLog.ReviewID.Eq(reviewID)).
+		Order(r.data.query.ReviewOperationLog.ID).
+		Find()
+}
@@ -0,0 +1,118 @@
+package data
+
+import (
+	"context"
+	goodsv1 "review-service/api/goods/v1"
+	orderv1 "review-service/api/order/v1"
+	"review-service/internal/biz"
+	"review-service/internal/conf"
+	"time"
+
+	"github.com/go-kratos/kratos/contrib/registry/consul/v2"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/go-kratos/kratos/v2/transport/grpc"
+	consulAPI "github.com/hashicorp/consul/api"
+)
+
+func NewDiscovery(conf *conf.Registry) registry.Discovery {
+	c := consulAPI.DefaultConfig()
+	c.Address = conf.Consul.Address
+	c.Scheme = conf.Consul.Scheme
+	cli, err := consulAPI.NewClient(c)
+	if err != nil {
+		panic(err)
+	}
+	return consul.New(cli, consul.WithHealthCheck(true))
+}
+
+func NewOrderServiceClient(d registry.Discovery) orderv1.OrderClient {
+	conn, err := grpc.DialInsecure(
+		context.Background(),
+		grpc.WithEndpoint("discovery:///order.service"),
+		grpc.WithDiscovery(d),
+		grpc.WithMiddleware(
+			recovery.Recovery()))
+	if err != nil {
+		panic(err)
+	}
+	return orderv1.NewOrderClient(conn)
+}
+
+func NewGoodsServiceClient(d registry.Discovery) goodsv1.GoodsClient {
+	conn, err := grpc.DialInsecure(
+		context.Background(),
+		grpc.WithEndpoint("discovery:///goods.service"),
+		grpc.WithDiscovery(d),
+		grpc.WithMiddleware(
+			recovery.Recovery()))
+	if err != nil {
+		panic(err)
+	}
+	return goodsv1.NewGoodsClient(conn)
+}
+
+// orderRepo 通过RPC调用订单服务
+type orderRepo struct {
+	oc  orderv1.OrderClient
+	log *log.Helper
+}
+
+func NewOrderRepo(oc orderv1.OrderClient, logger log.Logger) biz.OrderRepo {
+	return &orderRepo{
+		oc:  oc,
+		log: log.NewHelper(logger),
+	}
+}
+
+func (r *orderRepo) GetOrder(ctx context.Context, orderID int64) (*biz.Order, error) {
+	ret, err := r.oc.GetOrder(ctx, &orderv1.GetOrderRequest{OrderID: orderID})
+	r.log.WithContext(ctx).Debugf("GetOrder reply ret:%v, err:%v", ret, err)
+	if err != nil {
+		return nil, err
+	}
+	order := &biz.Order{
+		OrderID: ret.GetOrderID(),
+		UserID:  ret.GetUserID(),
+		StoreID: ret.GetStoreID(),
+		Status:  ret.GetStatus(),
+		Items:   make([]*biz.OrderItem, 0, len(ret.GetItems())),
+
+		CompleteAt: time.Unix(ret.GetCompleteAt(), 0),
+	}
+	for _, item := range ret.GetItems() {
+		order.Items = append(order.Items, &biz.OrderItem{SkuID: item.GetSkuID(), SpuID: item.GetSpuID()})
+	}
+	return order, nil
+}
+
+// goodsRepo 通过RPC调用商品服务
+type goodsRepo struct {
+	gc  goodsv1.GoodsClient
+	log *log.Helper
+}
+
+func NewGoodsRepo(gc goodsv1.GoodsClient, logger log.Logger) biz.GoodsRepo {
+	return &goodsRepo{
+		gc:  gc,
+		log: log.NewHelper(logger),
+	}
+}
+
+func (r *goodsRepo) GetGoods(ctx context.Context, skuID int64) (*biz.Goods, error) {
+	ret, err := r.gc.GetSku(ctx, &goodsv1.GetSkuRequest{SkuID: skuID})
+	r.log.WithContext(ctx).Debugf("GetSku reply ret:%v, err:%v", ret, err)
+	if err != nil {
+		return nil, err
+	}
+	return &biz.Goods{
+		SkuID:   ret.GetSkuID(),
+		SpuID:   ret.GetSpuID(),
+		StoreID: ret.GetStoreID(),
+		Title:   ret.GetTitle(),
+		Price:   ret.GetPrice(),
+		Pic:     ret.GetPic(),
+		Specs:   ret.GetSpecs(),
+	}, nil
+}
@@ -16,44 +16,69 @@ import (
 )
 
 var (
-	Q                = new(Query)
-	ReviewAppealInfo *reviewAppealInfo
-	ReviewInfo       *reviewInfo
-	ReviewReplyInfo  *reviewReplyInfo
+	Q                  = new(Query)
+	ReviewAppealInfo   *reviewAppealInfo
+	ReviewFollowUpInfo *reviewFollowUpInfo
+	ReviewInfo         *reviewInfo
+	ReviewOperationLog *reviewOperationLog
+	ReviewReplyHistory *reviewReplyHistory
+	ReviewReplyInfo    *reviewReplyInfo
+	ReviewRevision     *reviewRevision
+	ReviewStoreSummary *reviewStoreSummary
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
 	ReviewAppealInfo = &Q.ReviewAppealInfo
+	ReviewFollowUpInfo = &Q.ReviewFollowUpInfo
 	ReviewInfo = &Q.ReviewInfo
+	ReviewOperationLog = &Q.ReviewOperationLog
+	ReviewReplyHistory = &Q.ReviewReplyHistory
 	ReviewReplyInfo = &Q.ReviewReplyInfo
+	ReviewRevision = &Q.ReviewRevision
+	ReviewStoreSummary = &Q.ReviewStoreSummary
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:               db,
-		ReviewAppealInfo: newReviewAppealInfo(db, opts...),
-		ReviewInfo:       newReviewInfo(db, opts...),
-		ReviewReplyInfo:  newReviewReplyInfo(db, opts...),
+		db:                 db,
+		ReviewAppealInfo:   newReviewAppealInfo(db, opts...),
+		ReviewFollowUpInfo: newReviewFollowUpInfo(db, opts...),
+		ReviewInfo:         newReviewInfo(db, opts...),
+		ReviewOperationLog: newReviewOperationLog(db, opts...),
+		ReviewReplyHistory: newReviewReplyHistory(db, opts...),
+		ReviewReplyInfo:    newReviewReplyInfo(db, opts...),
+		ReviewRevision:     newReviewRevision(db, opts...),
+		ReviewStoreSummary: newReviewStoreSummary(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	ReviewAppealInfo reviewAppealInfo
-	ReviewInfo       reviewInfo
-	ReviewReplyInfo  reviewReplyInfo
+	ReviewAppealInfo   reviewAppealInfo
+	ReviewFollowUpInfo reviewFollowUpInfo
+	ReviewInfo         reviewInfo
+	ReviewOperationLog reviewOperationLog
+	ReviewReplyHistory reviewReplyHistory
+	ReviewReplyInfo    reviewReplyInfo
+	ReviewRevision     reviewRevision
+	ReviewStoreSummary reviewStoreSummary
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:               db,
-		ReviewAppealInfo: q.ReviewAppealInfo.clone(db),
-		ReviewInfo:       q.ReviewInfo.clone(db),
-		ReviewReplyInfo:  q.ReviewReplyInfo.clone(db),
+		db:                 db,
+		ReviewAppealInfo:   q.ReviewAppealInfo.clone(db),
+		ReviewFollowUpInfo: q.ReviewFollowUpInfo.clone(db),
+		ReviewInfo:         q.ReviewInfo.clone(db),
+		ReviewOperationLog: q.ReviewOperationLog.clone(db),
+		ReviewReplyHistory: q.ReviewReplyHistory.clone(db),
+		ReviewReplyInfo:    q.ReviewReplyInfo.clone(db),
+		ReviewRevision:     q.ReviewRevision.clone(db),
+		ReviewStoreSummary: q.ReviewStoreSummary.clone(db),
 	}
 }
 
@@ -67,24 +92,39 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:               db,
-		ReviewAppealInfo: q.ReviewAppealInfo.replaceDB(db),
-		ReviewInfo:       q.ReviewInfo.replaceDB(db),
-		ReviewReplyInfo:  q.ReviewReplyInfo.replaceDB(db),
+		db:                 db,
+		ReviewAppealInfo:   q.ReviewAppealInfo.replaceDB(db),
+		ReviewFollowUpInfo: q.ReviewFollowUpInfo.replaceDB(db),
+		ReviewInfo:         q.ReviewInfo.replaceDB(db),
+		ReviewOperationLog: q.ReviewOperationLog.replaceDB(db),
+		ReviewReplyHistory: q.ReviewReplyHistory.replaceDB(db),
+		ReviewReplyInfo:    q.ReviewReplyInfo.replaceDB(db),
+		ReviewRevision:     q.ReviewRevision.replaceDB(db),
+		ReviewStoreSummary: q.ReviewStoreSummary.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	ReviewAppealInfo IReviewAppealInfoDo
-	ReviewInfo       IReviewInfoDo
-	ReviewReplyInfo  IReviewReplyInfoDo
+	ReviewAppealInfo   IReviewAppealInfoDo
+	ReviewFollowUpInfo IReviewFollowUpInfoDo
+	ReviewInfo         IReviewInfoDo
+	ReviewOperationLog IReviewOperationLogDo
+	ReviewReplyHistory IReviewReplyHistoryDo
+	ReviewReplyInfo    IReviewReplyInfoDo
+	ReviewRevision     IReviewRevisionDo
+	ReviewStoreSummary IReviewStoreSummaryDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		ReviewAppealInfo: q.ReviewAppealInfo.WithContext(ctx),
-		ReviewInfo:       q.ReviewInfo.WithContext(ctx),
-		ReviewReplyInfo:  q.ReviewReplyInfo.WithContext(ctx),
+		ReviewAppealInfo:   q.ReviewAppealInfo.WithContext(ctx),
+		ReviewFollowUpInfo: q.ReviewFollowUpInfo.WithContext(ctx),
+		ReviewInfo:         q.ReviewInfo.WithContext(ctx),
+		ReviewOperationLog: q.ReviewOperationLog.WithContext(ctx),
+		ReviewReplyHistory: q.ReviewReplyHistory.WithContext(ctx),
+		ReviewReplyInfo:    q.ReviewReplyInfo.WithContext(ctx),
+		ReviewRevision:     q.ReviewRevision.WithContext(ctx),
+		ReviewStoreSummary: q.ReviewStoreSummary.WithContext(ctx),
 	}
 }
 
@@ -32,7 +32,7 @@ func newReviewInfo(db *gorm.DB, opts ...gen.DOOption) reviewInfo {
 	_reviewInfo.UpdateBy = field.NewString(tableName, "update_by")
 	_reviewInfo.CreateAt = field.NewTime(tableName, "create_at")
 	_reviewInfo.UpdateAt = field.NewTime(tableName, "update_at")
-	_reviewInfo.DeleteAt = field.NewTime(tableName, "delete_at")
+	_reviewInfo.DeleteAt = field.NewField(tableName, "delete_at")
 	_reviewInfo.Version = field.NewInt32(tableName, "version")
 	_reviewInfo.ReviewID = field.NewInt64(tableName, "review_id")
 	_reviewInfo.Content = field.NewString(tableName, "content")
@@ -73,7 +73,7 @@ type reviewInfo struct {
 	UpdateBy       field.String // 更新方标识
 	CreateAt       field.Time   // 创建时间
 	UpdateAt       field.Time   // 更新时间
-	DeleteAt       field.Time   // 逻辑删除标记
+	DeleteAt       field.Field  // 逻辑删除标记
 	Version        field.Int32  // 乐观锁标记
 	ReviewID       field.Int64  // 评价id
 	Content        field.String // 评价内容
@@ -120,7 +120,7 @@ func (r *reviewInfo) updateTableName(table string) *reviewInfo {
 	r.UpdateBy = field.NewString(table, "update_by")
 	r.CreateAt = field.NewTime(table, "create_at")
 	r.UpdateAt = field.NewTime(table, "update_at")
-	r.DeleteAt = field.NewTime(table, "delete_at")
+	r.DeleteAt = field.NewField(table, "delete_at")
 	r.Version = field.NewInt32(table, "version")
 	r.ReviewID = field.NewInt64(table, "review_id")
 	r.Content = field.NewString(table, "content")
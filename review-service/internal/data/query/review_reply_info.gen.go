@@ -32,7 +32,7 @@ func newReviewReplyInfo(db *gorm.DB, opts ...gen.DOOption) reviewReplyInfo {
 	_reviewReplyInfo.UpdateBy = field.NewString(tableName, "update_by")
 	_reviewReplyInfo.CreateAt = field.NewTime(tableName, "create_at")
 	_reviewReplyInfo.UpdateAt = field.NewTime(tableName, "update_at")
-	_reviewReplyInfo.DeleteAt = field.NewTime(tableName, "delete_at")
+	_reviewReplyInfo.DeleteAt = field.NewField(tableName, "delete_at")
 	_reviewReplyInfo.Version = field.NewInt32(tableName, "version")
 	_reviewReplyInfo.ReplyID = field.NewInt64(tableName, "reply_id")
 	_reviewReplyInfo.ReviewID = field.NewInt64(tableName, "review_id")
@@ -57,7 +57,7 @@ type reviewReplyInfo struct {
 	UpdateBy  field.String // 更新方标识
 	CreateAt  field.Time   // 创建时间
 	UpdateAt  field.Time   // 更新时间
-	DeleteAt  field.Time   // 逻辑删除标记
+	DeleteAt  field.Field  // 逻辑删除标记
 	Version   field.Int32  // 乐观锁标记
 	ReplyID   field.Int64  // 回复id
 	ReviewID  field.Int64  // 评价id
@@ -88,7 +88,7 @@ func (r *reviewReplyInfo) updateTableName(table string) *reviewReplyInfo {
 	r.UpdateBy = field.NewString(table, "update_by")
 	r.CreateAt = field.NewTime(table, "create_at")
 	r.UpdateAt = field.NewTime(table, "update_at")
-	r.DeleteAt = field.NewTime(table, "delete_at")
+	r.DeleteAt = field.NewField(table, "delete_at")
 	r.Version = field.NewInt32(table, "version")
 	r.ReplyID = field.NewInt64(table, "reply_id")
 	r.ReviewID = field.NewInt64(table, "review_id")
@@ -0,0 +1,123 @@
+package data
+
+import (
+	"context"
+	v1 "review-service/api/review/v1"
+	"review-service/internal/biz"
+	"review-service/internal/data/model"
+	"review-service/internal/data/query"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// GetReply 根据回复ID查询回复
+func (r *reviewRepo) GetReply(ctx context.Context, replyID int64) (*model.ReviewReplyInfo, error) {
+	return r.data.query.ReviewReplyInfo.
+		WithContext(ctx).
+		Where(r.data.query.ReviewReplyInfo.ReplyID.Eq(replyID)).
+		First()
+}
+
+// UpdateReply 修改回复，修改前的内容写入回复历史表
+func (r *reviewRepo) UpdateReply(ctx context.Context, reply *model.ReviewReplyInfo, param *biz.UpdateReplyParam) error {
+	return r.data.query.Transaction(func(tx *query.Query) error {
+		if err := saveReplyHistory(ctx, tx, reply, biz.ReplyActionUpdate); err != nil {
+			return err
+		}
+		info, err := tx.ReviewReplyInfo.
+			WithContext(ctx).
+			Where(
+				tx.ReviewReplyInfo.ReplyID.Eq(param.ReplyID),
+				tx.ReviewReplyInfo.Version.Eq(param.Version),
+			).
+			Updates(map[string]interface{}{
+				"content":    param.Content,
+				"pic_info":   param.PicInfo,
+				"video_info": param.VideoInfo,
+				"ext_json":   param.ExtJSON,
+				"version":    gorm.Expr("version + 1"),
+			})
+		if err != nil {
+			return err
+		}
+		if info.RowsAffected == 0 {
+			return v1.ErrorVersionConflict("回复:%d已被修改，请重试", param.ReplyID)
+		}
+		return writeReplyLog(ctx, tx, reply, biz.OpActionUpdate)
+	})
+}
+
+// DeleteReply 撤回回复
+// 回复内容写入回复历史表后物理删除，评价变为未回复，这样商家可以重新回复（review_id上有唯一索引）
+func (r *reviewRepo) DeleteReply(ctx context.Context, reply *model.ReviewReplyInfo, param *biz.DeleteReplyParam) error {
+	return r.data.query.Transaction(func(tx *query.Query) error {
+		if err := saveReplyHistory(ctx, tx, reply, biz.ReplyActionDelete); err != nil {
+			return err
+		}
+		info, err := tx.ReviewReplyInfo.
+			WithContext(ctx).
+			Unscoped().
+			Where(
+				tx.ReviewReplyInfo.ReplyID.Eq(param.ReplyID),
+				tx.ReviewReplyInfo.Version.Eq(param.Version),
+			).
+			Delete()
+		if err != nil {
+			return err
+		}
+		if info.RowsAffected == 0 {
+			return v1.ErrorVersionConflict("回复:%d已被修改，请重试", param.ReplyID)
+		}
+		if err := writeReplyLog(ctx, tx, reply, biz.OpActionWithdraw); err != nil {
+			return err
+		}
+		// 评价表清除has_reply
+		if _, err := tx.ReviewInfo.
+			WithContext(ctx).
+			Where(
+				tx.ReviewInfo.ReviewID.Eq(reply.ReviewID),
+				tx.ReviewInfo.HasReply.Eq(1),
+			).
+			Updates(map[string]interface{}{
+				"has_reply": 0,
+				"version":   gorm.Expr("version + 1"),
+			}); err != nil {
+			return err
+		}
+		review, err := getReviewInTx(ctx, tx, reply.ReviewID)
+		if err != nil {
+			return err
+		}
+		return changeStoreReplyCount(ctx, tx, review, -1)
+	})
+}
+
+// writeReplyLog 记录商家修改或撤回回复，回复没有状态
+func writeReplyLog(ctx context.Context, tx *query.Query, reply *model.ReviewReplyInfo, action int32) error {
+	return writeOperationLog(ctx, tx, &model.ReviewOperationLog{
+		ReviewID:   reply.ReviewID,
+		TargetType: biz.OpTargetReply,
+		TargetID:   reply.ReplyID,
+		Action:     action,
+		Actor:      strconv.FormatInt(reply.StoreID, 10),
+		Role:       biz.OpRoleMerchant,
+	})
+}
+
+// saveReplyHistory 保存回复被修改或撤回前的内容
+func saveReplyHistory(ctx context.Context, tx *query.Query, reply *model.ReviewReplyInfo, action int32) error {
+	return tx.ReviewReplyHistory.
+		WithContext(ctx).
+		Create(&model.ReviewReplyHistory{
+			ReplyID:       reply.ReplyID,
+			ReviewID:      reply.ReviewID,
+			StoreID:       reply.StoreID,
+			Action:        action,
+			ReplyVersion:  reply.Version,
+			Content:       reply.Content,
+			PicInfo:       reply.PicInfo,
+			VideoInfo:     reply.VideoInfo,
+			ReplyCreateAt: reply.CreateAt,
+		})
+}
@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/sortorder"
 	"github.com/redis/go-redis/v9"
 	"golang.org/x/sync/singleflight"
+	v1 "review-service/api/review/v1"
 	"review-service/internal/biz"
 	"review-service/internal/data/model"
 	"review-service/internal/data/query"
@@ -35,20 +38,90 @@ func NewReviewRepo(data *Data, logger log.Logger) biz.ReviewRepo {
 }
 
 func (r *reviewRepo) SaveReview(ctx context.Context, review *model.ReviewInfo) (*model.ReviewInfo, error) {
-	err := r.data.query.ReviewInfo.
+	err := r.data.query.Transaction(func(tx *query.Query) error {
+		return createReview(ctx, tx, review)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// SaveReviews 在一个事务中批量保存同一订单下多个商品的评价
+func (r *reviewRepo) SaveReviews(ctx context.Context, reviews []*model.ReviewInfo) ([]*model.ReviewInfo, error) {
+	err := r.data.query.Transaction(func(tx *query.Query) error {
+		for _, review := range reviews {
+			if err := createReview(ctx, tx, review); err != nil {
+				r.log.WithContext(ctx).Errorf("SaveReviews create review fail, err:%v", err)
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// createReview 在事务中创建评价，机审直接通过的评价同时计入店铺评分汇总
+func createReview(ctx context.Context, tx *query.Query, review *model.ReviewInfo) error {
+	// 注意不能用Save，Save是 INSERT ... ON DUPLICATE KEY UPDATE，唯一索引冲突时会覆盖已有的评价
+	err := tx.ReviewInfo.
 		WithContext(ctx).
-		Save(review)
-	return review, err
+		Create(review)
+	if isDuplicateEntry(err) {
+		// 并发创建时由唯一索引uk_order_sku兜底
+		return v1.ErrorOrderReviewed("订单:%d商品:%d已评价", review.OrderID, review.SkuID)
+	}
+	if err != nil {
+		return err
+	}
+	// 默认评价由系统创建，其余为用户创建，创建后的状态由机审决定
+	actor, role := strconv.FormatInt(review.UserID, 10), biz.OpRoleUser
+	if review.IsDefault == 1 {
+		actor, role = biz.OpActorSystem, biz.OpRoleSystem
+	}
+	if err := writeOperationLog(ctx, tx, &model.ReviewOperationLog{
+		ReviewID:    review.ReviewID,
+		TargetType:  biz.OpTargetReview,
+		TargetID:    review.ReviewID,
+		Action:      biz.OpActionCreate,
+		Actor:       actor,
+		Role:        role,
+		AfterStatus: review.Status,
+		Reason:      review.OpReason,
+	}); err != nil {
+		return err
+	}
+	if review.Status == biz.ReviewStatusApproved {
+		return changeStoreSummary(ctx, tx, review, 1)
+	}
+	return nil
 }
 
-// GetReviewByOrderID 根据订单ID查询评价
+// GetReviewByOrderID 根据订单ID查询评价，包含已删除的评价
+// 逻辑删除的评价仍然占用唯一索引uk_order_sku，判断订单商品是否评价过时要一起查出来
 func (r *reviewRepo) GetReviewByOrderID(ctx context.Context, orderID int64) ([]*model.ReviewInfo, error) {
 	return r.data.query.ReviewInfo.
 		WithContext(ctx).
+		Unscoped().
 		Where(r.data.query.ReviewInfo.OrderID.Eq(orderID)).
 		Find()
 }
 
+// GetReviewByOrderSku 根据订单ID和商品skuID查询评价，包含已删除的评价
+func (r *reviewRepo) GetReviewByOrderSku(ctx context.Context, orderID, skuID int64) ([]*model.ReviewInfo, error) {
+	return r.data.query.ReviewInfo.
+		WithContext(ctx).
+		Unscoped().
+		Where(
+			r.data.query.ReviewInfo.OrderID.Eq(orderID),
+			r.data.query.ReviewInfo.SkuID.Eq(skuID),
+		).
+		Find()
+}
+
 func (r *reviewRepo) GetReview(ctx context.Context, reviewID int64) (*model.ReviewInfo, error) {
 	return r.data.query.ReviewInfo.
 		WithContext(ctx).
@@ -57,47 +130,65 @@ func (r *reviewRepo) GetReview(ctx context.Context, reviewID int64) (*model.Revi
 }
 
 // SaveReply 保存评价回复
-func (r *reviewRepo) SaveReply(ctx context.Context, reply *model.ReviewReplyInfo) (*model.ReviewReplyInfo, error) {
-	// 1. 数据校验
-	// 1.1 数据合法性校验（已回复的评价不允许商家再次回复）
-	// 先用评价ID查库,看下是否已回复
-	review, err := r.data.query.ReviewInfo.
-		WithContext(ctx).
-		Where(r.data.query.ReviewInfo.ReviewID.Eq(reply.ReviewID)).
-		First()
-	if err != nil {
-		return nil, err
-	}
-	if review.HasReply == 1 {
-		return nil, errors.New("该评价已回复")
-	}
-	// 1.2 水平越权校验（A商家只能回复自己的不能回复B商家的）
-	// 举例子：用户A删除订单，userID + orderID 当条件去查询订单然后删除
-	if review.StoreID != reply.StoreID {
-		return nil, errors.New("水平越权")
-	}
-	// 2. 更新数据库中的数据（评价回复表和评价表要同时更新，涉及到事务操作）
+// 水平越权和乐观锁版本在biz层校验，是否已回复在事务中通过 has_reply = 0 条件更新保证
+func (r *reviewRepo) SaveReply(ctx context.Context, reply *model.ReviewReplyInfo, version int32) (*model.ReviewReplyInfo, error) {
+	// 更新数据库中的数据（评价回复表和评价表要同时更新，涉及到事务操作）
 	// 事务操作
-	err = r.data.query.Transaction(func(tx *query.Query) error {
-		// 回复表插入一条数据
+	err := r.data.query.Transaction(func(tx *query.Query) error {
+		// 1. 评价表更新hasReply字段，只有未回复的评价才能更新成功，同时校验并递增版本号
+		info, err := tx.ReviewInfo.
+			WithContext(ctx).
+			Where(
+				tx.ReviewInfo.ReviewID.Eq(reply.ReviewID),
+				tx.ReviewInfo.HasReply.Eq(0),
+				tx.ReviewInfo.Version.Eq(version),
+			).
+			Updates(map[string]interface{}{
+				"has_reply": 1,
+				"version":   gorm.Expr("version + 1"),
+			})
+		if err != nil {
+			r.log.WithContext(ctx).Errorf("SaveReply update review fail, err:%v", err)
+			return err
+		}
+		review, err := getReviewInTx(ctx, tx, reply.ReviewID)
+		if err != nil {
+			return err
+		}
+		if info.RowsAffected == 0 {
+			// 区分是被别人抢先回复了还是评价被修改了
+			if review.HasReply == 1 {
+				return v1.ErrorReviewAlreadyReplied("评价:%d已回复", reply.ReviewID)
+			}
+			return v1.ErrorVersionConflict("评价:%d已被修改，请重试", reply.ReviewID)
+		}
+		// 2. 回复表插入一条数据，不能用Save，由唯一索引uk_review_id兜底
 		if err := tx.ReviewReplyInfo.
 			WithContext(ctx).
-			Save(reply); err != nil {
+			Create(reply); err != nil {
 			r.log.WithContext(ctx).Errorf("SaveReply create reply fail, err:%v", err)
+			if isDuplicateEntry(err) {
+				return v1.ErrorReviewAlreadyReplied("评价:%d已回复", reply.ReviewID)
+			}
 			return err
 		}
-		// 评价表更新hasReply字段
-		if _, err := tx.ReviewInfo.
-			WithContext(ctx).
-			Where(tx.ReviewInfo.ReviewID.Eq(reply.ReviewID)).
-			Update(tx.ReviewInfo.HasReply, 1); err != nil {
-			r.log.WithContext(ctx).Errorf("SaveReply update review fail, err:%v", err)
+		if err := writeOperationLog(ctx, tx, &model.ReviewOperationLog{
+			ReviewID:   reply.ReviewID,
+			TargetType: biz.OpTargetReply,
+			TargetID:   reply.ReplyID,
+			Action:     biz.OpActionCreate,
+			Actor:      strconv.FormatInt(reply.StoreID, 10),
+			Role:       biz.OpRoleMerchant,
+		}); err != nil {
 			return err
 		}
-		return nil
+		return changeStoreReplyCount(ctx, tx, review, 1)
 	})
+	if err != nil {
+		return nil, err
+	}
 	// 3. 返回
-	return reply, err
+	return reply, nil
 }
 
 // GetReviewReply 获取评价回复
@@ -110,97 +201,253 @@ func (r *reviewRepo) GetReviewReply(ctx context.Context, reviewID int64) (*model
 
 // AuditReview 审核评价（运营对用户的评价进行审核）
 func (r *reviewRepo) AuditReview(ctx context.Context, param *biz.AuditParam) error {
-	_, err := r.data.query.ReviewInfo.
-		WithContext(ctx).
-		Where(r.data.query.ReviewInfo.ReviewID.Eq(param.ReviewID)).
-		Updates(map[string]interface{}{
-			"status":     param.Status,
-			"op_user":    param.OpUser,
-			"op_reason":  param.OpReason,
-			"op_remarks": param.OpRemarks,
-		})
-	return err
+	return r.data.query.Transaction(func(tx *query.Query) error {
+		review, err := getReviewInTx(ctx, tx, param.ReviewID)
+		if err != nil {
+			return err
+		}
+		// 乐观锁：按biz层读到的版本号条件更新，并递增版本号
+		info, err := tx.ReviewInfo.
+			WithContext(ctx).
+			Where(
+				tx.ReviewInfo.ReviewID.Eq(param.ReviewID),
+				tx.ReviewInfo.Version.Eq(param.Version),
+			).
+			Updates(map[string]interface{}{
+				"status":     param.Status,
+				"op_user":    param.OpUser,
+				"op_reason":  param.OpReason,
+				"op_remarks": param.OpRemarks,
+				"version":    gorm.Expr("version + 1"),
+			})
+		if err != nil {
+			return err
+		}
+		if info.RowsAffected == 0 {
+			return v1.ErrorVersionConflict("评价:%d已被修改，请重试", param.ReviewID)
+		}
+		if err := writeOperationLog(ctx, tx, &model.ReviewOperationLog{
+			ReviewID:     param.ReviewID,
+			TargetType:   biz.OpTargetReview,
+			TargetID:     param.ReviewID,
+			Action:       biz.OpActionAudit,
+			Actor:        param.OpUser,
+			Role:         biz.OpRoleOperator,
+			BeforeStatus: review.Status,
+			AfterStatus:  param.Status,
+			Reason:       param.OpReason,
+			Remarks:      param.OpRemarks,
+		}); err != nil {
+			return err
+		}
+		// 评价变为审核通过时计入店铺评分汇总，审核通过的评价被隐藏时扣除
+		// 评分在审核中不会变化，用审核前读到的评价即可
+		switch {
+		case review.Status != biz.ReviewStatusApproved && param.Status == biz.ReviewStatusApproved:
+			return changeStoreSummary(ctx, tx, review, 1)
+		case review.Status == biz.ReviewStatusApproved && param.Status != biz.ReviewStatusApproved:
+			return changeStoreSummary(ctx, tx, review, -1)
+		}
+		return nil
+	})
 }
 
 // AppealReview 申诉评价（商家对用户评价进行申诉）
+// 待审核的申诉直接修改；被驳回或撤回后重新提交时新建一条申诉，提交次数不能超过上限
 func (r *reviewRepo) AppealReview(ctx context.Context, param *biz.AppealParam) (*model.ReviewAppealInfo, error) {
-	// 先查询有没有申诉
-	ret, err := r.data.query.ReviewAppealInfo.
-		WithContext(ctx).
-		Where(
-			query.ReviewAppealInfo.ReviewID.Eq(param.ReviewID),
-			query.ReviewAppealInfo.StoreID.Eq(param.StoreID),
-		).First()
-	r.log.Debugf("AppealReview query, ret:%v err:%v", ret, err)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		// 其他查询错误
-		return nil, err
-	}
-	if err == nil && ret.Status > 10 {
-		return nil, errors.New("该评价已有审核过的申诉记录")
-	}
-	// 查询不到审核过的申诉记录
-	// 1. 有申诉记录但是处于待审核状态，需要更新
-	// if ret != nil{
-	// 	// update
-	// }else{
-	// 	// insert
-	// }
-	// 2. 没有申诉记录，需要创建
 	appeal := &model.ReviewAppealInfo{
 		ReviewID:  param.ReviewID,
 		StoreID:   param.StoreID,
-		Status:    10,
+		Status:    biz.AppealStatusPending,
 		Reason:    param.Reason,
 		Content:   param.Content,
 		PicInfo:   param.PicInfo,
 		VideoInfo: param.VideoInfo,
 	}
-	if ret != nil {
-		appeal.AppealID = ret.AppealID
-	} else {
+	err := r.data.query.Transaction(func(tx *query.Query) error {
+		// 锁住评价，避免同一条评价并发提交出多个待审核的申诉
+		if _, err := tx.ReviewInfo.
+			WithContext(ctx).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(tx.ReviewInfo.ReviewID.Eq(param.ReviewID)).
+			First(); err != nil {
+			return err
+		}
+		// 先查询有没有申诉，最新的一条在最前面
+		appeals, err := tx.ReviewAppealInfo.
+			WithContext(ctx).
+			Where(tx.ReviewAppealInfo.ReviewID.Eq(param.ReviewID)).
+			Order(tx.ReviewAppealInfo.ID.Desc()).
+			Find()
+		r.log.Debugf("AppealReview query, len(appeals):%d err:%v", len(appeals), err)
+		if err != nil {
+			return err
+		}
+		if len(appeals) > 0 {
+			last := appeals[0]
+			switch last.Status {
+			case biz.AppealStatusPending:
+				// 1. 有待审核的申诉，按版本号条件更新
+				if err := biz.CheckVersion(param.ExpectedVersion, last.Version); err != nil {
+					return err
+				}
+				info, err := tx.ReviewAppealInfo.
+					WithContext(ctx).
+					Where(
+						tx.ReviewAppealInfo.AppealID.Eq(last.AppealID),
+						tx.ReviewAppealInfo.Version.Eq(last.Version),
+					).
+					Updates(map[string]interface{}{
+						"content":    appeal.Content,
+						"reason":     appeal.Reason,
+						"pic_info":   appeal.PicInfo,
+						"video_info": appeal.VideoInfo,
+						"version":    gorm.Expr("version + 1"),
+					})
+				if err != nil {
+					return err
+				}
+				if info.RowsAffected == 0 {
+					return v1.ErrorVersionConflict("申诉:%d已被修改，请重试", last.AppealID)
+				}
+				appeal.AppealID = last.AppealID
+				appeal.SubmitCount = last.SubmitCount
+				appeal.DeadlineAt = last.DeadlineAt
+				appeal.EscalateAt = last.EscalateAt
+				appeal.Version = last.Version + 1
+				return writeAppealLog(ctx, tx, param, appeal, biz.OpActionUpdate)
+			case biz.AppealStatusApproved:
+				return v1.ErrorAppealStatusInvalid("评价:%d的申诉已通过", param.ReviewID)
+			}
+		}
+		// 2. 没有申诉或者之前的申诉被驳回、撤回，需要新建一条申诉
+		if int32(len(appeals)) >= param.MaxSubmitTimes {
+			return v1.ErrorAppealLimitExceeded("评价:%d最多只能申诉%d次", param.ReviewID, param.MaxSubmitTimes)
+		}
 		appeal.AppealID = snowflake.GenID()
+		appeal.SubmitCount = int32(len(appeals)) + 1
+		appeal.DeadlineAt = param.DeadlineAt
+		if err := tx.ReviewAppealInfo.
+			WithContext(ctx).
+			Create(appeal); err != nil {
+			return err
+		}
+		return writeAppealLog(ctx, tx, param, appeal, biz.OpActionCreate)
+	})
+	r.log.Debugf("AppealReview, err:%v", err)
+	if err != nil {
+		return nil, err
 	}
-	err = r.data.query.ReviewAppealInfo.
+	return appeal, nil
+}
+
+// writeAppealLog 记录商家提交或修改申诉，申诉原因类别记在操作原因中
+func writeAppealLog(ctx context.Context, tx *query.Query, param *biz.AppealParam, appeal *model.ReviewAppealInfo, action int32) error {
+	actor := param.OpUser
+	if len(actor) == 0 {
+		actor = strconv.FormatInt(param.StoreID, 10)
+	}
+	var before int32
+	if action == biz.OpActionUpdate {
+		before = biz.AppealStatusPending
+	}
+	return writeOperationLog(ctx, tx, &model.ReviewOperationLog{
+		ReviewID:     appeal.ReviewID,
+		TargetType:   biz.OpTargetAppeal,
+		TargetID:     appeal.AppealID,
+		Action:       action,
+		Actor:        actor,
+		Role:         biz.OpRoleMerchant,
+		BeforeStatus: before,
+		AfterStatus:  appeal.Status,
+		Reason:       appeal.Reason,
+	})
+}
+
+// GetAppeal 根据申诉ID查询申诉
+func (r *reviewRepo) GetAppeal(ctx context.Context, appealID int64) (*model.ReviewAppealInfo, error) {
+	return r.data.query.ReviewAppealInfo.
 		WithContext(ctx).
-		Clauses(clause.OnConflict{
-			Columns: []clause.Column{
-				{Name: "review_id"}, // ON DUPLICATE KEY，唯一索引
-			},
-			// 在review_id冲突时，更新，否则创建
-			DoUpdates: clause.Assignments(map[string]interface{}{ // UPDATE
-				"status":     appeal.Status,
-				"content":    appeal.Content,
-				"reason":     appeal.Reason,
-				"pic_info":   appeal.PicInfo,
-				"video_info": appeal.VideoInfo,
-			}),
-		}).
-		Create(appeal) // INSERT
-	r.log.Debugf("AppealReview, err:%v", err)
-	return appeal, err
+		Where(r.data.query.ReviewAppealInfo.AppealID.Eq(appealID)).
+		First()
 }
 
 // AuditAppeal 审核申诉（运营对商家的申诉进行审核，审核通过会隐藏该评价）
 func (r *reviewRepo) AuditAppeal(ctx context.Context, param *biz.AuditAppealParam) error {
 	err := r.data.query.Transaction(func(tx *query.Query) error {
 		// 申诉表
-		if _, err := tx.ReviewAppealInfo.
+		info, err := tx.ReviewAppealInfo.
 			WithContext(ctx).
-			Where(r.data.query.ReviewAppealInfo.AppealID.Eq(param.AppealID)).
+			Where(
+				tx.ReviewAppealInfo.AppealID.Eq(param.AppealID),
+				tx.ReviewAppealInfo.Version.Eq(param.Version),
+			).
 			Updates(map[string]interface{}{
-				"status":  param.Status,
-				"op_user": param.OpUser,
-			}); err != nil {
+				"status":     param.Status,
+				"op_user":    param.OpUser,
+				"op_reason":  param.OpReason,
+				"op_remarks": param.OpRemarks,
+				"version":    gorm.Expr("version + 1"),
+			})
+		if err != nil {
 			return err
 		}
-		// 评价表
-		if param.Status == 20 { // 申诉通过则需要隐藏评价
-			if _, err := tx.ReviewInfo.WithContext(ctx).
-				Where(tx.ReviewInfo.ReviewID.Eq(param.ReviewID)).
-				Update(tx.ReviewInfo.Status, 40); err != nil {
+		if info.RowsAffected == 0 {
+			return v1.ErrorVersionConflict("申诉:%d已被修改，请重试", param.AppealID)
+		}
+		// 按biz层读到的版本号更新成功，说明审核前申诉一定是待审核状态
+		if err := writeOperationLog(ctx, tx, &model.ReviewOperationLog{
+			ReviewID:     param.ReviewID,
+			TargetType:   biz.OpTargetAppeal,
+			TargetID:     param.AppealID,
+			Action:       biz.OpActionAudit,
+			Actor:        param.OpUser,
+			Role:         biz.OpRoleOperator,
+			BeforeStatus: biz.AppealStatusPending,
+			AfterStatus:  param.Status,
+			Reason:       param.OpReason,
+			Remarks:      param.OpRemarks,
+		}); err != nil {
+			return err
+		}
+		// 评价表，申诉驳回时评价保持原状态
+		if param.Status == biz.AppealStatusApproved { // 申诉通过则需要隐藏评价
+			review, err := getReviewInTx(ctx, tx, param.ReviewID)
+			if err != nil {
+				return err
+			}
+			info, err := tx.ReviewInfo.WithContext(ctx).
+				Where(
+					tx.ReviewInfo.ReviewID.Eq(param.ReviewID),
+					tx.ReviewInfo.Version.Eq(param.ReviewVersion),
+				).
+				Updates(map[string]interface{}{
+					"status":  biz.ReviewStatusHidden,
+					"version": gorm.Expr("version + 1"),
+				})
+			if err != nil {
+				return err
+			}
+			if info.RowsAffected == 0 {
+				return v1.ErrorVersionConflict("评价:%d已被修改，请重试", param.ReviewID)
+			}
+			if err := writeOperationLog(ctx, tx, &model.ReviewOperationLog{
+				ReviewID:     param.ReviewID,
+				TargetType:   biz.OpTargetReview,
+				TargetID:     param.ReviewID,
+				Action:       biz.OpActionHide,
+				Actor:        param.OpUser,
+				Role:         biz.OpRoleOperator,
+				BeforeStatus: review.Status,
+				AfterStatus:  biz.ReviewStatusHidden,
+				Reason:       param.OpReason,
+			}); err != nil {
 				return err
 			}
+			// 隐藏审核通过的评价，需要从店铺评分汇总中扣除
+			if review.Status == biz.ReviewStatusApproved {
+				return changeStoreSummary(ctx, tx, review, -1)
+			}
 		}
 		return nil
 	})
@@ -208,19 +455,27 @@ func (r *reviewRepo) AuditAppeal(ctx context.Context, param *biz.AuditAppealPara
 }
 
 // ListReviewByUserID 根据userID查询所有评价
-func (r *reviewRepo) ListReviewByUserID(ctx context.Context, userID int64, offset, limit int) ([]*model.ReviewInfo, error) {
-	return r.data.query.ReviewInfo.
+// 有游标时按自增id向后翻页，避免大offset慢查询
+func (r *reviewRepo) ListReviewByUserID(ctx context.Context, userID int64, token *biz.PageToken, offset, limit int) ([]*model.ReviewInfo, error) {
+	q := r.data.query.ReviewInfo.
 		WithContext(ctx).
-		Where(r.data.query.ReviewInfo.UserID.Eq(userID)).
-		Order(r.data.query.ReviewInfo.ID.Desc()).
+		Where(r.data.query.ReviewInfo.UserID.Eq(userID))
+	if token != nil && token.LastID > 0 {
+		q = q.Where(r.data.query.ReviewInfo.ID.Lt(token.LastID))
+	} else {
+		q = q.Offset(offset)
+	}
+	return q.Order(r.data.query.ReviewInfo.ID.Desc()).
 		Limit(limit).
-		Offset(offset).
 		Find()
 }
 
 // ListReviewByStoreID 根据storeID分页查询
-func (r *reviewRepo) ListReviewByStoreID(ctx context.Context, storeID int64, offset, limit int) ([]*biz.MyReviewInfo, error) {
-
+func (r *reviewRepo) ListReviewByStoreID(ctx context.Context, storeID int64, token *biz.PageToken, offset, limit int) ([]*biz.MyReviewInfo, *biz.PageToken, error) {
+	// 游标翻页直接查es，不走缓存
+	if token != nil {
+		return r.searchAfterFromES(ctx, storeID, token, limit)
+	}
 	return r.getData2(ctx, storeID, offset, limit)
 	//去es中查询
 	//return r.getData1(ctx, storeID, offset, limit)
@@ -242,6 +497,12 @@ func (r *reviewRepo) getData1(ctx context.Context, storeID int64, offset int, li
 						},
 					},
 				},
+				// 过滤掉已经逻辑删除的评价
+				MustNot: []types.Query{
+					{
+						Exists: &types.ExistsQuery{Field: "delete_at"},
+					},
+				},
 			},
 		}).Do(ctx)
 
@@ -266,8 +527,13 @@ func (r *reviewRepo) getData1(ctx context.Context, storeID int64, offset int, li
 
 var g singleflight.Group
 
+const (
+	reviewIndex  = "review"
+	pitKeepAlive = "1m" // 翻页间隔超过这个时间PIT会失效
+)
+
 // 升级版，带缓存
-func (r *reviewRepo) getData2(ctx context.Context, storeID int64, offset, limit int) ([]*biz.MyReviewInfo, error) {
+func (r *reviewRepo) getData2(ctx context.Context, storeID int64, offset, limit int) ([]*biz.MyReviewInfo, *biz.PageToken, error) {
 	// 1.先查询缓存
 
 	// 2.缓存没有则查es
@@ -275,23 +541,132 @@ func (r *reviewRepo) getData2(ctx context.Context, storeID int64, offset, limit
 	key := fmt.Sprintf("review:%d:%d:%d", storeID, offset, limit)
 	data, err := r.getDataBySingleflight(ctx, key)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	hm := new(types.HitsMetadata)
 	if err := json.Unmarshal(data, hm); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	// 反序列化
-	list := make([]*biz.MyReviewInfo, 0, hm.Total.Value)
-	for _, hit := range hm.Hits {
+	list := unmarshalHits(r.log, hm.Hits)
+	// 按page/size查询时也返回游标，下一页可以切换到search_after
+	return list, nextPageToken("", hm.Hits), nil
+}
+
+// searchAfterFromES 使用point in time + search_after游标翻页
+// 第一次翻页时创建PIT，之后的翻页复用令牌里的PIT，保证翻页过程中数据视图一致
+func (r *reviewRepo) searchAfterFromES(ctx context.Context, storeID int64, token *biz.PageToken, limit int) ([]*biz.MyReviewInfo, *biz.PageToken, error) {
+	pitID := token.PitID
+	if len(pitID) == 0 {
+		pit, err := r.data.es.OpenPointInTime(reviewIndex).KeepAlive(pitKeepAlive).Do(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		pitID = pit.Id
+	}
+	searchAfter := make([]types.FieldValue, 0, len(token.SearchAfter))
+	for _, v := range token.SearchAfter {
+		searchAfter = append(searchAfter, v)
+	}
+	// 使用PIT时不能指定索引
+	resp, err := r.data.es.Search().
+		Request(&search.Request{
+			Size:        &limit,
+			Query:       storeReviewQuery(storeID),
+			Sort:        reviewSort(),
+			SearchAfter: searchAfter,
+			Pit: &types.PointInTimeReference{
+				Id:        pitID,
+				KeepAlive: pitKeepAlive,
+			},
+		}).Do(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	// PIT id在每次查询后可能变化，以最新的为准
+	if resp.PitId != nil {
+		pitID = *resp.PitId
+	}
+	return unmarshalHits(r.log, resp.Hits.Hits), nextPageToken(pitID, resp.Hits.Hits), nil
+}
+
+// nextPageToken 根据最后一条记录的排序值生成下一页的令牌
+func nextPageToken(pitID string, hits []types.Hit) *biz.PageToken {
+	if len(hits) == 0 {
+		return nil
+	}
+	last := hits[len(hits)-1]
+	token := &biz.PageToken{
+		PitID:       pitID,
+		SearchAfter: make([]interface{}, 0, len(last.Sort)),
+	}
+	for _, v := range last.Sort {
+		token.SearchAfter = append(token.SearchAfter, v)
+	}
+	// 评价id超过了float64能精确表示的范围，反序列化后的排序值会丢失精度，改用文档中原始的评价id
+	if len(token.SearchAfter) == len(reviewSort()) {
+		doc := struct {
+			ReviewID json.Number `json:"review_id"`
+		}{}
+		if err := json.Unmarshal(last.Source_, &doc); err == nil && len(doc.ReviewID) > 0 {
+			token.SearchAfter[len(token.SearchAfter)-1] = doc.ReviewID.String()
+		}
+	}
+	return token
+}
+
+// unmarshalHits 将es返回的文档反序列化为评价
+func unmarshalHits(logger *log.Helper, hits []types.Hit) []*biz.MyReviewInfo {
+	list := make([]*biz.MyReviewInfo, 0, len(hits))
+	for _, hit := range hits {
 		temp := &biz.MyReviewInfo{}
 		if err := json.Unmarshal(hit.Source_, temp); err != nil {
-			r.log.Errorf("ReviewInfoJson Unmarshal err:%v", err)
+			logger.Errorf("ReviewInfoJson Unmarshal err:%v", err)
 			continue
 		}
 		list = append(list, temp)
 	}
-	return list, nil
+	return list
+}
+
+// storeReviewQuery 查询店铺下未删除的评价
+func storeReviewQuery(storeID interface{}) *types.Query {
+	return &types.Query{
+		Bool: &types.BoolQuery{
+			Filter: []types.Query{
+				{
+					Term: map[string]types.TermQuery{
+						"store_id": {
+							Value: storeID,
+						},
+					},
+				},
+			},
+			// 过滤掉已经逻辑删除的评价
+			MustNot: []types.Query{
+				{
+					Exists: &types.ExistsQuery{Field: "delete_at"},
+				},
+			},
+		},
+	}
+}
+
+// reviewSort 按创建时间倒序，同一时间创建的按评价id倒序，评价id唯一，保证search_after翻页不重复不遗漏
+// create_at和review_id在评价索引的mapping中分别是date和long类型（见review-job的reviewIndexMapping）
+func reviewSort() []types.SortCombinations {
+	return []types.SortCombinations{
+		types.SortOptions{
+			SortOptions: map[string]types.FieldSort{
+				"create_at": {Order: &sortorder.Desc},
+			},
+		},
+		types.SortOptions{
+			SortOptions: map[string]types.FieldSort{
+				"review_id": {Order: &sortorder.Desc},
+			},
+		},
+	}
 }
 
 // key review:231231:1:10
@@ -349,20 +724,11 @@ func (r *reviewRepo) getDataFromES(ctx context.Context, key string) ([]byte, err
 		return nil, err
 	}
 	resp, err := r.data.es.Search().Index(index).
-		From(offset).
-		Size(limit).
-		Query(&types.Query{
-			Bool: &types.BoolQuery{
-				Filter: []types.Query{
-					{
-						Term: map[string]types.TermQuery{
-							"store_id": {
-								Value: storeID,
-							},
-						},
-					},
-				},
-			},
+		Request(&search.Request{
+			From:  &offset,
+			Size:  &limit,
+			Query: storeReviewQuery(storeID),
+			Sort:  reviewSort(),
 		}).Do(ctx)
 
 	if err != nil {
@@ -0,0 +1,66 @@
+package data
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+)
+
+// 店铺评价列表的查询条件和排序，按create_at、review_id倒序
+func TestStoreReviewSearchRequest(t *testing.T) {
+	size := 10
+	b, err := json.Marshal(&search.Request{
+		Size:  &size,
+		Query: storeReviewQuery(int64(1)),
+		Sort:  reviewSort(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := struct {
+		Query json.RawMessage   `json:"query"`
+		Sort  []json.RawMessage `json:"sort"`
+	}{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{`{"create_at":{"order":"desc"}}`, `{"review_id":{"order":"desc"}}`}
+	if len(got.Sort) != len(want) {
+		t.Fatalf("sort = %s, want %v", b, want)
+	}
+	for i, w := range want {
+		if string(got.Sort[i]) != w {
+			t.Fatalf("sort[%d] = %s, want %s", i, got.Sort[i], w)
+		}
+	}
+	wantQuery := `{"bool":{"filter":[{"term":{"store_id":{"value":1}}}],"must_not":[{"exists":{"field":"delete_at"}}]}}`
+	if string(got.Query) != wantQuery {
+		t.Fatalf("query = %s, want %s", got.Query, wantQuery)
+	}
+}
+
+// 翻页令牌中的评价id取文档中的原始值，不能用丢失精度的排序值
+func TestNextPageTokenKeepsReviewID(t *testing.T) {
+	hits := []types.Hit{
+		{
+			Source_: json.RawMessage(`{"review_id":"1844674407370955161","create_at":"2026-10-18 12:00:00"}`),
+			Sort:    []types.FieldValue{float64(1760788800000), float64(1844674407370955161)},
+		},
+	}
+	token := nextPageToken("pit", hits)
+	b, err := json.Marshal(token.SearchAfter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[1760788800000,"1844674407370955161"]`; string(b) != want {
+		t.Fatalf("search_after = %s, want %s", b, want)
+	}
+	if token.PitID != "pit" {
+		t.Fatalf("pit = %s, want pit", token.PitID)
+	}
+	if nextPageToken("", nil) != nil {
+		t.Fatal("no hits should have no next page")
+	}
+}
@@ -0,0 +1,92 @@
+package data
+
+import (
+	"context"
+	v1 "review-service/api/review/v1"
+	"review-service/internal/biz"
+	"review-service/internal/data/model"
+	"review-service/internal/data/query"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// UpdateReview 修改评价，修改前的内容写入评价修改历史表，评价重新进入待审核
+func (r *reviewRepo) UpdateReview(ctx context.Context, review *model.ReviewInfo, param *biz.UpdateReviewParam) error {
+	return r.data.query.Transaction(func(tx *query.Query) error {
+		if err := tx.ReviewRevision.
+			WithContext(ctx).
+			Create(&model.ReviewRevision{
+				ReviewID:     review.ReviewID,
+				UserID:       review.UserID,
+				Revision:     review.Version,
+				Content:      review.Content,
+				Score:        review.Score,
+				ServiceScore: review.ServiceScore,
+				ExpressScore: review.ExpressScore,
+				HasMedia:     review.HasMedia,
+				PicInfo:      review.PicInfo,
+				VideoInfo:    review.VideoInfo,
+				Status:       review.Status,
+				ExtJSON:      review.ExtJSON,
+				CtrlJSON:     review.CtrlJSON,
+			}); err != nil {
+			return err
+		}
+		info, err := tx.ReviewInfo.
+			WithContext(ctx).
+			Where(
+				tx.ReviewInfo.ReviewID.Eq(param.ReviewID),
+				tx.ReviewInfo.Version.Eq(param.Version),
+			).
+			Updates(map[string]interface{}{
+				"score":         param.Score,
+				"service_score": param.ServiceScore,
+				"express_score": param.ExpressScore,
+				"content":       param.Content,
+				"pic_info":      param.PicInfo,
+				"video_info":    param.VideoInfo,
+				"has_media":     param.HasMedia,
+				"is_default":    0, // 用户修改过的默认评价不再是默认评价
+				"status":        biz.ReviewStatusPending,
+				"op_user":       "",
+				"op_reason":     param.OpReason,
+				"op_remarks":    "",
+				"ext_json":      param.ExtJSON,
+				"version":       gorm.Expr("version + 1"),
+			})
+		if err != nil {
+			return err
+		}
+		if info.RowsAffected == 0 {
+			return v1.ErrorVersionConflict("评价:%d已被修改，请重试", param.ReviewID)
+		}
+		if err := writeOperationLog(ctx, tx, &model.ReviewOperationLog{
+			ReviewID:     param.ReviewID,
+			TargetType:   biz.OpTargetReview,
+			TargetID:     param.ReviewID,
+			Action:       biz.OpActionUpdate,
+			Actor:        strconv.FormatInt(param.UserID, 10),
+			Role:         biz.OpRoleUser,
+			BeforeStatus: review.Status,
+			AfterStatus:  biz.ReviewStatusPending,
+			Reason:       param.OpReason,
+		}); err != nil {
+			return err
+		}
+		// 审核通过的评价重新进入待审核，需要按修改前的评分从店铺评分汇总中扣除
+		if review.Status == biz.ReviewStatusApproved {
+			return changeStoreSummary(ctx, tx, review, -1)
+		}
+		return nil
+	})
+}
+
+// ListReviewRevisions 查询评价的修改历史
+func (r *reviewRepo) ListReviewRevisions(ctx context.Context, reviewID int64) ([]*model.ReviewRevision, error) {
+	return r.data.query.ReviewRevision.
+		WithContext(ctx).
+		Where(r.data.query.ReviewRevision.ReviewID.Eq(reviewID)).
+		Order(r.data.query.ReviewRevision.Revision).
+		Find()
+}
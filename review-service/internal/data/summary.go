@@ -0,0 +1,95 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"review-service/internal/biz"
+	"review-service/internal/data/model"
+	"review-service/internal/data/query"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetStoreSummary 查询店铺评分汇总，店铺还没有审核通过的评价时返回空的汇总
+func (r *reviewRepo) GetStoreSummary(ctx context.Context, storeID int64) (*model.ReviewStoreSummary, error) {
+	summary, err := r.data.query.ReviewStoreSummary.
+		WithContext(ctx).
+		Where(r.data.query.ReviewStoreSummary.StoreID.Eq(storeID)).
+		First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &model.ReviewStoreSummary{StoreID: storeID}, nil
+	}
+	return summary, err
+}
+
+// getReviewInTx 在事务中查询评价，用于维护店铺评分汇总
+func getReviewInTx(ctx context.Context, tx *query.Query, reviewID int64) (*model.ReviewInfo, error) {
+	return tx.ReviewInfo.
+		WithContext(ctx).
+		Where(tx.ReviewInfo.ReviewID.Eq(reviewID)).
+		First()
+}
+
+// changeStoreSummary 增量更新店铺评分汇总，必须和评价状态变更在同一个事务中调用
+// delta为1表示评价变为审核通过，为-1表示审核通过的评价被隐藏或删除
+func changeStoreSummary(ctx context.Context, tx *query.Query, review *model.ReviewInfo, delta int32) error {
+	// 店铺第一条评价审核通过时插入汇总数据，之后按store_id唯一索引累加
+	summary := &model.ReviewStoreSummary{
+		StoreID:         review.StoreID,
+		ReviewCount:     delta,
+		ScoreSum:        int64(delta * review.Score),
+		ServiceScoreSum: int64(delta * review.ServiceScore),
+		ExpressScoreSum: int64(delta * review.ExpressScore),
+	}
+	updates := map[string]interface{}{
+		"review_count":      gorm.Expr("review_count + ?", delta),
+		"score_sum":         gorm.Expr("score_sum + ?", delta*review.Score),
+		"service_score_sum": gorm.Expr("service_score_sum + ?", delta*review.ServiceScore),
+		"express_score_sum": gorm.Expr("express_score_sum + ?", delta*review.ExpressScore),
+	}
+	switch review.Score {
+	case 1:
+		summary.Star1Count = delta
+	case 2:
+		summary.Star2Count = delta
+	case 3:
+		summary.Star3Count = delta
+	case 4:
+		summary.Star4Count = delta
+	case 5:
+		summary.Star5Count = delta
+	}
+	if review.Score >= 1 && review.Score <= 5 {
+		column := fmt.Sprintf("star_%d_count", review.Score)
+		updates[column] = gorm.Expr(column+" + ?", delta)
+	}
+	if review.HasMedia == 1 {
+		summary.MediaCount = delta
+		updates["media_count"] = gorm.Expr("media_count + ?", delta)
+	}
+	if review.HasReply == 1 {
+		summary.ReplyCount = delta
+		updates["reply_count"] = gorm.Expr("reply_count + ?", delta)
+	}
+	return tx.ReviewStoreSummary.
+		WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "store_id"}}, // 唯一索引uk_store_id
+			DoUpdates: clause.Assignments(updates),
+		}).
+		Create(summary)
+}
+
+// changeStoreReplyCount 审核通过的评价被回复或撤回回复时，更新店铺的回复数
+func changeStoreReplyCount(ctx context.Context, tx *query.Query, review *model.ReviewInfo, delta int32) error {
+	if review.Status != biz.ReviewStatusApproved {
+		return nil
+	}
+	_, err := tx.ReviewStoreSummary.
+		WithContext(ctx).
+		Where(tx.ReviewStoreSummary.StoreID.Eq(review.StoreID)).
+		Update(tx.ReviewStoreSummary.ReplyCount, gorm.Expr("reply_count + ?", delta))
+	return err
+}
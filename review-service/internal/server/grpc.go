@@ -1,22 +1,34 @@
 package server
 
 import (
+	"context"
+
 	"github.com/go-kratos/kratos/v2/middleware/validate"
 	v1 "review-service/api/review/v1"
 	"review-service/internal/conf"
 	"review-service/internal/service"
 
+	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/middleware/selector"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 )
 
+// internalOperations 只提供给内部服务调用的接口，和internalPaths对应，公网gRPC服务上不开放
+var internalOperations = map[string]bool{
+	v1.OperationReviewCreateDefaultReviews: true, // review-job创建默认好评
+	v1.OperationReviewCheckAppealSLA:       true, // review-job申诉时效检查
+}
+
 // NewGRPCServer new a gRPC server.
 // 改 review参数
 func NewGRPCServer(c *conf.Server, review *service.ReviewService, logger log.Logger) *grpc.Server {
 	var opts = []grpc.ServerOption{
 		grpc.Middleware(
 			recovery.Recovery(),
+			denyInternalOperations(),
 			validate.Validator(),
 			//v2.ProtoValidate(),
 		),
@@ -34,3 +46,14 @@ func NewGRPCServer(c *conf.Server, review *service.ReviewService, logger log.Log
 	v1.RegisterReviewServer(srv, review) // 改
 	return srv
 }
+
+// denyInternalOperations 公网gRPC服务上调用内部接口时返回NotFound
+func denyInternalOperations() middleware.Middleware {
+	return selector.Server(func(middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errors.NotFound("NOT_FOUND", "接口不存在")
+		}
+	}).Match(func(ctx context.Context, operation string) bool {
+		return internalOperations[operation]
+	}).Build()
+}
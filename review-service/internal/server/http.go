@@ -1,6 +1,8 @@
 package server
 
 import (
+	nethttp "net/http"
+
 	"github.com/go-kratos/kratos/v2/middleware/validate"
 	v1 "review-service/api/review/v1"
 	"review-service/internal/conf"
@@ -11,6 +13,12 @@ import (
 	"github.com/go-kratos/kratos/v2/transport/http"
 )
 
+// internalPaths 只提供给内部服务调用的接口，公网HTTP服务上不开放，gRPC见internalOperations
+var internalPaths = map[string]bool{
+	"/v1/review/default":   true, // review-job创建默认好评
+	"/v1/appeal/sla/check": true, // review-job申诉时效检查
+}
+
 // NewHTTPServer new an HTTP server.
 func NewHTTPServer(c *conf.Server, review *service.ReviewService, logger log.Logger) *http.Server {
 	var opts = []http.ServerOption{
@@ -18,6 +26,7 @@ func NewHTTPServer(c *conf.Server, review *service.ReviewService, logger log.Log
 			recovery.Recovery(),
 			validate.Validator(),
 		),
+		http.Filter(denyInternalPaths),
 	}
 	if c.Http.Network != "" {
 		opts = append(opts, http.Network(c.Http.Network))
@@ -32,3 +41,41 @@ func NewHTTPServer(c *conf.Server, review *service.ReviewService, logger log.Log
 	v1.RegisterReviewHTTPServer(srv, review)
 	return srv
 }
+
+// InternalHTTPServer 只在内网监听的HTTP服务，和公网HTTP服务类型不同，方便wire区分注入
+type InternalHTTPServer struct {
+	*http.Server
+}
+
+// NewInternalHTTPServer 内网HTTP服务，除了公网的接口，还开放internalPaths中的内部接口
+func NewInternalHTTPServer(c *conf.Server, review *service.ReviewService, logger log.Logger) *InternalHTTPServer {
+	var opts = []http.ServerOption{
+		http.Middleware(
+			recovery.Recovery(),
+			validate.Validator(),
+		),
+	}
+	if c.InternalHttp.GetNetwork() != "" {
+		opts = append(opts, http.Network(c.InternalHttp.GetNetwork()))
+	}
+	if c.InternalHttp.GetAddr() != "" {
+		opts = append(opts, http.Address(c.InternalHttp.GetAddr()))
+	}
+	if c.InternalHttp.GetTimeout() != nil {
+		opts = append(opts, http.Timeout(c.InternalHttp.GetTimeout().AsDuration()))
+	}
+	srv := http.NewServer(opts...)
+	v1.RegisterReviewHTTPServer(srv, review)
+	return &InternalHTTPServer{Server: srv}
+}
+
+// denyInternalPaths 公网HTTP服务上访问内部接口时返回404
+func denyInternalPaths(next nethttp.Handler) nethttp.Handler {
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		if internalPaths[r.URL.Path] {
+			nethttp.NotFound(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -10,7 +10,7 @@ import (
 )
 
 // ProviderSet is server providers.
-var ProviderSet = wire.NewSet(NewRegister, NewGRPCServer, NewHTTPServer)
+var ProviderSet = wire.NewSet(NewRegister, NewGRPCServer, NewHTTPServer, NewInternalHTTPServer)
 
 func NewRegister(conf *conf.Registry) registry.Registrar {
 	c := api.DefaultConfig()
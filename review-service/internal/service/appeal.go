@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"review-service/internal/biz"
+	"review-service/internal/data/model"
+	"time"
+
+	pb "review-service/api/review/v1"
+)
+
+// GetAppeal B端商家查询申诉详情
+func (s *ReviewService) GetAppeal(ctx context.Context, req *pb.GetAppealRequest) (*pb.GetAppealReply, error) {
+	fmt.Printf("[service] GetAppeal req:%#v\n", req)
+	appeal, err := s.uc.GetAppeal(ctx, req.GetAppealID(), req.GetStoreID())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetAppealReply{Data: toAppealInfo(appeal)}, nil
+}
+
+// ListAppealByStoreID B端商家查询店铺的申诉列表
+func (s *ReviewService) ListAppealByStoreID(ctx context.Context, req *pb.ListAppealByStoreIDRequest) (*pb.ListAppealByStoreIDReply, error) {
+	fmt.Printf("[service] ListAppealByStoreID req:%#v\n", req)
+	ret, err := s.uc.ListAppealByStoreID(ctx, req.GetStoreID(), req.GetStatus(), int(req.GetPage()), int(req.GetSize()))
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*pb.AppealInfo, 0, len(ret))
+	for _, v := range ret {
+		list = append(list, toAppealInfo(v))
+	}
+	return &pb.ListAppealByStoreIDReply{List: list}, nil
+}
+
+// CheckAppealSLA 扫描超时的申诉，由review-job定时调用
+func (s *ReviewService) CheckAppealSLA(ctx context.Context, req *pb.CheckAppealSLARequest) (*pb.CheckAppealSLAReply, error) {
+	fmt.Printf("[service] CheckAppealSLA req:%#v\n", req)
+	escalated, closed, err := s.uc.CheckAppealSLA(ctx, int(req.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CheckAppealSLAReply{Escalated: int32(escalated), Closed: int32(closed)}, nil
+}
+
+// ListEscalatedAppeals O端查询高级审核队列中的申诉
+func (s *ReviewService) ListEscalatedAppeals(ctx context.Context, req *pb.ListEscalatedAppealsRequest) (*pb.ListEscalatedAppealsReply, error) {
+	fmt.Printf("[service] ListEscalatedAppeals req:%#v\n", req)
+	ret, err := s.uc.ListEscalatedAppeals(ctx, int(req.GetPage()), int(req.GetSize()))
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*pb.AppealInfo, 0, len(ret))
+	for _, v := range ret {
+		list = append(list, toAppealInfo(v))
+	}
+	return &pb.ListEscalatedAppealsReply{List: list}, nil
+}
+
+func toAppealInfo(v *model.ReviewAppealInfo) *pb.AppealInfo {
+	return &pb.AppealInfo{
+		AppealID:    v.AppealID,
+		ReviewID:    v.ReviewID,
+		StoreID:     v.StoreID,
+		Status:      v.Status,
+		Reason:      v.Reason,
+		Content:     v.Content,
+		PicInfo:     v.PicInfo,
+		VideoInfo:   v.VideoInfo,
+		SubmitCount: v.SubmitCount,
+		OpReason:    v.OpReason,
+		OpRemarks:   v.OpRemarks,
+		Version:     v.Version,
+		DeadlineAt:  v.DeadlineAt.Format(time.DateTime),
+		SlaStatus:   biz.AppealSLAStatus(v, time.Now()),
+		CreateAt:    v.CreateAt.Format(time.DateTime),
+		UpdateAt:    v.UpdateAt.Format(time.DateTime),
+	}
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"review-service/internal/biz"
+	"review-service/internal/data/model"
+	"time"
+
+	pb "review-service/api/review/v1"
+)
+
+// ListPendingReviews O端查询待审核评价队列
+func (s *ReviewService) ListPendingReviews(ctx context.Context, req *pb.ListPendingReviewsRequest) (*pb.ListPendingReviewsReply, error) {
+	fmt.Printf("[service] ListPendingReviews req:%#v\n", req)
+	startAt, err := parseFilterTime(req.GetStartTime())
+	if err != nil {
+		return nil, err
+	}
+	endAt, err := parseFilterTime(req.GetEndTime())
+	if err != nil {
+		return nil, err
+	}
+	ret, err := s.uc.ListPendingReviews(ctx, &biz.ListPendingReviewParam{
+		StoreID:  req.GetStoreID(),
+		Score:    req.GetScore(),
+		HasMedia: req.HasMedia,
+		StartAt:  startAt,
+		EndAt:    endAt,
+	}, int(req.GetPage()), int(req.GetSize()))
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*pb.ReviewInfo, 0, len(ret))
+	for _, v := range ret {
+		list = append(list, toPendingReviewInfo(v))
+	}
+	return &pb.ListPendingReviewsReply{List: list}, nil
+}
+
+// ListPendingAppeals O端查询待审核申诉队列
+func (s *ReviewService) ListPendingAppeals(ctx context.Context, req *pb.ListPendingAppealsRequest) (*pb.ListPendingAppealsReply, error) {
+	fmt.Printf("[service] ListPendingAppeals req:%#v\n", req)
+	ret, err := s.uc.ListPendingAppeals(ctx, &biz.ListPendingAppealParam{
+		StoreID:   req.GetStoreID(),
+		Reason:    req.GetReason(),
+		SLAStatus: req.GetSlaStatus(),
+	}, int(req.GetPage()), int(req.GetSize()))
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*pb.AppealInfo, 0, len(ret))
+	for _, v := range ret {
+		list = append(list, toAppealInfo(v))
+	}
+	return &pb.ListPendingAppealsReply{List: list}, nil
+}
+
+// parseFilterTime 解析筛选条件中的时间，为空时返回零值表示不过滤
+func parseFilterTime(s string) (time.Time, error) {
+	if len(s) == 0 {
+		return time.Time{}, nil
+	}
+	t, err := time.ParseInLocation(time.DateTime, s, time.Local)
+	if err != nil {
+		return time.Time{}, pb.ErrorInvalidParam("时间格式错误:%s", s)
+	}
+	return t, nil
+}
+
+// toPendingReviewInfo 待审核队列中的评价，运营审核时需要带上版本号
+func toPendingReviewInfo(v *model.ReviewInfo) *pb.ReviewInfo {
+	return &pb.ReviewInfo{
+		UserID:       v.UserID,
+		ReviewID:     v.ReviewID,
+		OrderID:      v.OrderID,
+		StoreID:      v.StoreID,
+		SkuID:        v.SkuID,
+		Score:        v.Score,
+		ServiceScore: v.ServiceScore,
+		ExpressScore: v.ExpressScore,
+		Content:      v.Content,
+		PicInfo:      v.PicInfo,
+		VideoInfo:    v.VideoInfo,
+		HasMedia:     v.HasMedia,
+		Status:       v.Status,
+		OpReason:     v.OpReason,
+		Version:      v.Version,
+		CreateAt:     v.CreateAt.Format(time.DateTime),
+	}
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"review-service/internal/biz"
+
+	pb "review-service/api/review/v1"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+// BatchAuditReview O端批量审核评价
+func (s *ReviewService) BatchAuditReview(ctx context.Context, req *pb.BatchAuditReviewRequest) (*pb.BatchAuditReviewReply, error) {
+	fmt.Printf("[service] BatchAuditReview req:%#v\n", req)
+	ret, err := s.uc.BatchAuditReview(ctx, &biz.BatchAuditReviewParam{
+		ReviewIDs: req.GetReviewIDs(),
+		OpUser:    req.GetOpUser(),
+		OpReason:  req.GetOpReason(),
+		OpRemarks: req.GetOpRemarks(),
+		Status:    req.GetStatus(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BatchAuditReviewReply{Status: req.GetStatus(), List: toBatchAuditItems(ret)}, nil
+}
+
+// BatchAuditAppeal O端批量审核申诉
+func (s *ReviewService) BatchAuditAppeal(ctx context.Context, req *pb.BatchAuditAppealRequest) (*pb.BatchAuditAppealReply, error) {
+	fmt.Printf("[service] BatchAuditAppeal req:%#v\n", req)
+	ret, err := s.uc.BatchAuditAppeal(ctx, &biz.BatchAuditAppealParam{
+		AppealIDs: req.GetAppealIDs(),
+		OpUser:    req.GetOpUser(),
+		OpReason:  req.GetOpReason(),
+		OpRemarks: req.GetOpRemarks(),
+		Status:    req.GetStatus(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BatchAuditAppealReply{Status: req.GetStatus(), List: toBatchAuditItems(ret)}, nil
+}
+
+// toBatchAuditItems 单条失败时返回错误码和原因，方便运营按原因处理
+func toBatchAuditItems(results []*biz.BatchAuditResult) []*pb.BatchAuditItem {
+	list := make([]*pb.BatchAuditItem, 0, len(results))
+	for _, v := range results {
+		item := &pb.BatchAuditItem{ID: v.ID, Success: v.Err == nil}
+		if v.Err != nil {
+			e := errors.FromError(v.Err)
+			item.Code = e.Code
+			item.Reason = e.Reason
+			item.Message = e.Message
+		}
+		list = append(list, item)
+	}
+	return list
+}
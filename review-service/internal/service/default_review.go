@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"review-service/internal/data/model"
+
+	pb "review-service/api/review/v1"
+)
+
+// CreateDefaultReviews review-job调用，为超时未评价的订单商品创建默认好评
+func (s *ReviewService) CreateDefaultReviews(ctx context.Context, req *pb.CreateDefaultReviewsRequest) (*pb.CreateDefaultReviewsReply, error) {
+	fmt.Printf("[service] CreateDefaultReviews req:%#v\n", req)
+	items := make([]*model.ReviewInfo, 0, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		items = append(items, &model.ReviewInfo{
+			OrderID: item.GetOrderID(),
+			UserID:  item.GetUserID(),
+			StoreID: item.GetStoreID(),
+			SkuID:   item.GetSkuID(),
+			SpuID:   item.GetSpuID(),
+		})
+	}
+	ret, err := s.uc.CreateDefaultReviews(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+	reviewIDs := make([]int64, 0, len(ret))
+	for _, v := range ret {
+		reviewIDs = append(reviewIDs, v.ReviewID)
+	}
+	return &pb.CreateDefaultReviewsReply{ReviewIDs: reviewIDs}, nil
+}
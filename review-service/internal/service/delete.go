@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"review-service/internal/biz"
+
+	pb "review-service/api/review/v1"
+)
+
+// DeleteReview C端用户删除自己的评价
+func (s *ReviewService) DeleteReview(ctx context.Context, req *pb.DeleteReviewRequest) (*pb.DeleteReviewReply, error) {
+	fmt.Printf("[service] DeleteReview req:%#v\n", req)
+	err := s.uc.DeleteReview(ctx, &biz.DeleteReviewParam{
+		ReviewID: req.GetReviewID(),
+		UserID:   req.GetUserID(),
+
+		ExpectedVersion: req.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeleteReviewReply{ReviewID: req.GetReviewID()}, nil
+}
+
+// ForceDeleteReview O端运营强制删除评价
+func (s *ReviewService) ForceDeleteReview(ctx context.Context, req *pb.ForceDeleteReviewRequest) (*pb.ForceDeleteReviewReply, error) {
+	fmt.Printf("[service] ForceDeleteReview req:%#v\n", req)
+	err := s.uc.ForceDeleteReview(ctx, &biz.DeleteReviewParam{
+		ReviewID: req.GetReviewID(),
+		OpUser:   req.GetOpUser(),
+		OpReason: req.GetOpReason(),
+
+		ExpectedVersion: req.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ForceDeleteReviewReply{ReviewID: req.GetReviewID()}, nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"review-service/internal/biz"
+	"review-service/internal/data/model"
+
+	pb "review-service/api/review/v1"
+)
+
+// CreateFollowUp C端追评
+func (s *ReviewService) CreateFollowUp(ctx context.Context, req *pb.CreateFollowUpRequest) (*pb.CreateFollowUpReply, error) {
+	fmt.Printf("[service] CreateFollowUp req:%#v\n", req)
+	followUp, err := s.uc.CreateFollowUp(ctx, &biz.FollowUpParam{
+		ReviewID:  req.GetReviewID(),
+		UserID:    req.GetUserID(),
+		Content:   req.GetContent(),
+		PicInfo:   req.GetPicInfo(),
+		VideoInfo: req.GetVideoInfo(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CreateFollowUpReply{FollowUpID: followUp.FollowUpID}, nil
+}
+
+// GetFollowUp 获取追评详情
+func (s *ReviewService) GetFollowUp(ctx context.Context, req *pb.GetFollowUpRequest) (*pb.GetFollowUpReply, error) {
+	fmt.Printf("[service] GetFollowUp req:%#v\n", req)
+	followUp, err := s.uc.GetFollowUp(ctx, req.GetFollowUpID())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetFollowUpReply{Data: toFollowUpInfo(followUp)}, nil
+}
+
+// ListFollowUpByUserID 用户追评列表
+func (s *ReviewService) ListFollowUpByUserID(ctx context.Context, req *pb.ListFollowUpByUserIDRequest) (*pb.ListFollowUpByUserIDReply, error) {
+	fmt.Printf("[service] ListFollowUpByUserID req:%#v\n", req)
+	ret, err := s.uc.ListFollowUpByUserID(ctx, req.GetUserID(), int(req.GetPage()), int(req.GetSize()))
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*pb.FollowUpInfo, 0, len(ret))
+	for _, v := range ret {
+		list = append(list, toFollowUpInfo(v))
+	}
+	return &pb.ListFollowUpByUserIDReply{List: list}, nil
+}
+
+// AuditFollowUp O端审核追评
+func (s *ReviewService) AuditFollowUp(ctx context.Context, req *pb.AuditFollowUpRequest) (*pb.AuditFollowUpReply, error) {
+	fmt.Printf("[service] AuditFollowUp req:%#v\n", req)
+	err := s.uc.AuditFollowUp(ctx, &biz.AuditFollowUpParam{
+		FollowUpID: req.GetFollowUpID(),
+		OpUser:     req.GetOpUser(),
+		OpReason:   req.GetOpReason(),
+		OpRemarks:  req.GetOpRemarks(),
+		Status:     req.GetStatus(),
+
+		ExpectedVersion: req.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AuditFollowUpReply{FollowUpID: req.GetFollowUpID(), Status: req.GetStatus()}, nil
+}
+
+func toFollowUpInfo(v *model.ReviewFollowUpInfo) *pb.FollowUpInfo {
+	return &pb.FollowUpInfo{
+		FollowUpID: v.FollowUpID,
+		ReviewID:   v.ReviewID,
+		UserID:     v.UserID,
+		Content:    v.Content,
+		PicInfo:    v.PicInfo,
+		VideoInfo:  v.VideoInfo,
+		Status:     v.Status,
+	}
+}
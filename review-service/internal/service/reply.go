@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"review-service/internal/biz"
+
+	pb "review-service/api/review/v1"
+)
+
+// UpdateReply B端商家修改回复
+func (s *ReviewService) UpdateReply(ctx context.Context, req *pb.UpdateReplyRequest) (*pb.UpdateReplyReply, error) {
+	fmt.Printf("[service] UpdateReply req:%#v\n", req)
+	reply, err := s.uc.UpdateReply(ctx, &biz.UpdateReplyParam{
+		ReplyID:   req.GetReplyID(),
+		StoreID:   req.GetStoreID(),
+		Content:   req.GetContent(),
+		PicInfo:   req.GetPicInfo(),
+		VideoInfo: req.GetVideoInfo(),
+
+		ExpectedVersion: req.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UpdateReplyReply{ReplyID: reply.ReplyID, Version: reply.Version}, nil
+}
+
+// DeleteReply B端商家撤回回复
+func (s *ReviewService) DeleteReply(ctx context.Context, req *pb.DeleteReplyRequest) (*pb.DeleteReplyReply, error) {
+	fmt.Printf("[service] DeleteReply req:%#v\n", req)
+	err := s.uc.DeleteReply(ctx, &biz.DeleteReplyParam{
+		ReplyID: req.GetReplyID(),
+		StoreID: req.GetStoreID(),
+
+		ExpectedVersion: req.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeleteReplyReply{ReplyID: req.GetReplyID()}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"review-service/internal/biz"
 	"review-service/internal/data/model"
+	"time"
 
 	pb "review-service/api/review/v1"
 )
@@ -30,6 +31,7 @@ func (s *ReviewService) CreateReview(ctx context.Context, req *pb.CreateReviewRe
 	review, err := s.uc.CreateReview(ctx, &model.ReviewInfo{
 		UserID:       req.UserID,
 		OrderID:      req.OrderID,
+		SkuID:        req.SkuID,
 		Score:        req.Score,
 		ExpressScore: req.ExpressScore,
 		ServiceScore: req.ServiceScore,
@@ -38,13 +40,47 @@ func (s *ReviewService) CreateReview(ctx context.Context, req *pb.CreateReviewRe
 		VideoInfo:    req.VideoInfo,
 		Anonymous:    anonymous,
 		Status:       0,
-	})
+	}, req.GetIdempotencyKey())
 	if err != nil {
 		return nil, err
 	}
 	return &pb.CreateReviewReply{ReviewID: review.ReviewID}, nil
 }
 
+// BatchCreateReview 批量创建评价，同一订单下的每个商品各自一条评价
+func (s *ReviewService) BatchCreateReview(ctx context.Context, req *pb.BatchCreateReviewRequest) (*pb.BatchCreateReviewReply, error) {
+	fmt.Printf("[service] BatchCreateReview,req:%#v\n", req)
+	reviews := make([]*model.ReviewInfo, 0, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		var anonymous int32
+		if item.Anonymous {
+			anonymous = 1
+		}
+		reviews = append(reviews, &model.ReviewInfo{
+			UserID:       req.UserID,
+			OrderID:      req.OrderID,
+			SkuID:        item.SkuID,
+			Score:        item.Score,
+			ExpressScore: item.ExpressScore,
+			ServiceScore: item.ServiceScore,
+			Content:      item.Content,
+			PicInfo:      item.PicInfo,
+			VideoInfo:    item.VideoInfo,
+			Anonymous:    anonymous,
+			Status:       0,
+		})
+	}
+	ret, err := s.uc.BatchCreateReview(ctx, reviews)
+	if err != nil {
+		return nil, err
+	}
+	reviewIDs := make([]int64, 0, len(ret))
+	for _, v := range ret {
+		reviewIDs = append(reviewIDs, v.ReviewID)
+	}
+	return &pb.BatchCreateReviewReply{ReviewIDs: reviewIDs}, nil
+}
+
 // ReplyReview 回复评价
 func (s *ReviewService) ReplyReview(ctx context.Context, req *pb.ReplyReviewRequest) (*pb.ReplyReviewReply, error) {
 	fmt.Printf("[service] ReplyReview req:%#v\n", req)
@@ -55,6 +91,8 @@ func (s *ReviewService) ReplyReview(ctx context.Context, req *pb.ReplyReviewRequ
 		Content:   req.GetContent(),
 		PicInfo:   req.GetPicInfo(),
 		VideoInfo: req.GetVideoInfo(),
+
+		ExpectedVersion: req.Version,
 	})
 	if err != nil {
 		return nil, err
@@ -62,25 +100,117 @@ func (s *ReviewService) ReplyReview(ctx context.Context, req *pb.ReplyReviewRequ
 	return &pb.ReplyReviewReply{ReplyID: reply.ReplyID}, nil
 }
 
+// AuditReview O端审核评价
+func (s *ReviewService) AuditReview(ctx context.Context, req *pb.AuditReviewRequest) (*pb.AuditReviewReply, error) {
+	fmt.Printf("[service] AuditReview req:%#v\n", req)
+	err := s.uc.AuditReview(ctx, &biz.AuditParam{
+		ReviewID:  req.GetReviewID(),
+		OpUser:    req.GetOpUser(),
+		OpReason:  req.GetOpReason(),
+		OpRemarks: req.GetOpRemarks(),
+		Status:    req.GetStatus(),
+
+		ExpectedVersion: req.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AuditReviewReply{ReviewID: req.GetReviewID(), Status: req.GetStatus()}, nil
+}
+
+// AppealReview B端商家申诉评价
 func (s *ReviewService) AppealReview(ctx context.Context, req *pb.AppealReviewRequest) (*pb.AppealReviewReply, error) {
-	return &pb.AppealReviewReply{}, nil
+	fmt.Printf("[service] AppealReview req:%#v\n", req)
+	appeal, err := s.uc.AppealReview(ctx, &biz.AppealParam{
+		ReviewID:  req.GetReviewID(),
+		StoreID:   req.GetStoreID(),
+		Reason:    req.GetReason(),
+		Content:   req.GetContent(),
+		PicInfo:   req.GetPicInfo(),
+		VideoInfo: req.GetVideoInfo(),
+		OpUser:    req.GetOpUser(),
+
+		ExpectedVersion: req.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AppealReviewReply{
+		AppealID:    appeal.AppealID,
+		Status:      appeal.Status,
+		SubmitCount: appeal.SubmitCount,
+		Version:     appeal.Version,
+	}, nil
+}
+
+// WithdrawAppeal B端商家撤回申诉
+func (s *ReviewService) WithdrawAppeal(ctx context.Context, req *pb.WithdrawAppealRequest) (*pb.WithdrawAppealReply, error) {
+	fmt.Printf("[service] WithdrawAppeal req:%#v\n", req)
+	err := s.uc.WithdrawAppeal(ctx, &biz.WithdrawAppealParam{
+		AppealID: req.GetAppealID(),
+		StoreID:  req.GetStoreID(),
+
+		ExpectedVersion: req.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.WithdrawAppealReply{AppealID: req.GetAppealID()}, nil
 }
+
+// AuditAppeal O端审核申诉
 func (s *ReviewService) AuditAppeal(ctx context.Context, req *pb.AuditAppealRequest) (*pb.AuditAppealReply, error) {
-	return &pb.AuditAppealReply{}, nil
+	fmt.Printf("[service] AuditAppeal req:%#v\n", req)
+	err := s.uc.AuditAppeal(ctx, &biz.AuditAppealParam{
+		ReviewID:  req.GetReviewID(),
+		StoreID:   req.GetStoreID(),
+		AppealID:  req.GetAppealID(),
+		OpUser:    req.GetOpUser(),
+		OpReason:  req.GetOpReason(),
+		OpRemarks: req.GetOpRemarks(),
+		Status:    req.GetStatus(),
+
+		ExpectedVersion: req.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AuditAppealReply{AppealID: req.GetAppealID(), Status: req.GetStatus()}, nil
 }
+
+// ListReviewByUserID C端查询用户的评价列表
 func (s *ReviewService) ListReviewByUserID(ctx context.Context, req *pb.ListReviewByUserIDRequest) (*pb.ListReviewByUserIDReply, error) {
-	return &pb.ListReviewByUserIDReply{}, nil
+	fmt.Printf("[service] ListReviewByUserID req:%#v\n", req)
+	ret, nextPageToken, err := s.uc.ListReviewByUserID(ctx, req.GetUserID(), int(req.GetPage()), int(req.GetSize()), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*pb.ReviewInfo, 0, len(ret))
+	for _, v := range ret {
+		list = append(list, &pb.ReviewInfo{
+			UserID:       v.UserID,
+			ReviewID:     v.ReviewID,
+			OrderID:      v.OrderID,
+			Score:        v.Score,
+			Content:      v.Content,
+			Status:       v.Status,
+			VideoInfo:    v.VideoInfo,
+			ServiceScore: v.ServiceScore,
+			ExpressScore: v.ExpressScore,
+		})
+	}
+	return &pb.ListReviewByUserIDReply{List: list, NextPageToken: nextPageToken}, nil
 }
 
 func (s *ReviewService) ListReviewByStoreID(ctx context.Context, req *pb.ListReviewByStoreIDRequest) (*pb.ListReviewByStoreIDReply, error) {
 	fmt.Printf("[service] ListReviewByStoreID req:%#v\n", req)
-	ret, err := s.uc.ListReviewByStoreID(ctx, req.StoreID, int(req.Page), int(req.Size))
+	ret, nextPageToken, err := s.uc.ListReviewByStoreID(ctx, req.StoreID, int(req.Page), int(req.Size), req.GetPageToken())
 	if err != nil {
 		return nil, err
 	}
 	list := make([]*pb.ReviewInfo, 0, len(ret))
 	for _, v := range ret {
-		list = append(list, &pb.ReviewInfo{
+		info := &pb.ReviewInfo{
 			UserID:       v.UserID,
 			ReviewID:     v.ReviewID,
 			OrderID:      v.OrderID,
@@ -90,7 +220,32 @@ func (s *ReviewService) ListReviewByStoreID(ctx context.Context, req *pb.ListRev
 			VideoInfo:    v.VideoInfo,
 			ServiceScore: v.ServiceScore,
 			ExpressScore: v.ExpressScore,
-		})
+		}
+		// 只展示审核通过的追评
+		if v.FollowUp != nil && v.FollowUp.Status == biz.ReviewStatusApproved {
+			info.FollowUp = &pb.FollowUpInfo{
+				FollowUpID: v.FollowUp.FollowUpID,
+				ReviewID:   v.ReviewID,
+				UserID:     v.UserID,
+				Content:    v.FollowUp.Content,
+				PicInfo:    v.FollowUp.PicInfo,
+				VideoInfo:  v.FollowUp.VideoInfo,
+				Status:     v.FollowUp.Status,
+			}
+		}
+		if v.Reply != nil {
+			info.Reply = &pb.ReplyInfo{
+				ReplyID:   v.Reply.ReplyID,
+				Content:   v.Reply.Content,
+				PicInfo:   v.Reply.PicInfo,
+				VideoInfo: v.Reply.VideoInfo,
+				CreateAt:  time.Time(v.Reply.CreateAt).Format(time.DateTime),
+			}
+		}
+		if v.Appeal != nil {
+			info.AppealStatus = v.Appeal.Status
+		}
+		list = append(list, info)
 	}
-	return &pb.ListReviewByStoreIDReply{List: list}, nil
+	return &pb.ListReviewByStoreIDReply{List: list, NextPageToken: nextPageToken}, nil
 }
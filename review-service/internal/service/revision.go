@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"review-service/internal/biz"
+	"review-service/internal/data/model"
+	"time"
+
+	pb "review-service/api/review/v1"
+)
+
+// UpdateReview C端用户修改评价
+func (s *ReviewService) UpdateReview(ctx context.Context, req *pb.UpdateReviewRequest) (*pb.UpdateReviewReply, error) {
+	fmt.Printf("[service] UpdateReview req:%#v\n", req)
+	review, err := s.uc.UpdateReview(ctx, &biz.UpdateReviewParam{
+		ReviewID:     req.GetReviewID(),
+		UserID:       req.GetUserID(),
+		Score:        req.GetScore(),
+		ServiceScore: req.GetServiceScore(),
+		ExpressScore: req.GetExpressScore(),
+		Content:      req.GetContent(),
+		PicInfo:      req.GetPicInfo(),
+		VideoInfo:    req.GetVideoInfo(),
+
+		ExpectedVersion: req.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UpdateReviewReply{ReviewID: review.ReviewID, Status: review.Status, Version: review.Version}, nil
+}
+
+// ListReviewRevisions O端查询评价的修改历史，最后一条为评价当前的内容
+func (s *ReviewService) ListReviewRevisions(ctx context.Context, req *pb.ListReviewRevisionsRequest) (*pb.ListReviewRevisionsReply, error) {
+	fmt.Printf("[service] ListReviewRevisions req:%#v\n", req)
+	review, revisions, err := s.uc.ListReviewRevisions(ctx, req.GetReviewID())
+	if err != nil {
+		return nil, err
+	}
+	// 每个版本的提交时间：第一个版本为评价创建时间，之后的版本为上一个版本被修改的时间
+	list := make([]*pb.ReviewRevision, 0, len(revisions)+1)
+	submitAt := review.CreateAt
+	for _, v := range revisions {
+		list = append(list, toReviewRevision(v, submitAt))
+		submitAt = v.CreateAt
+	}
+	list = append(list, &pb.ReviewRevision{
+		Revision:     review.Version,
+		Content:      review.Content,
+		Score:        review.Score,
+		ServiceScore: review.ServiceScore,
+		ExpressScore: review.ExpressScore,
+		PicInfo:      review.PicInfo,
+		VideoInfo:    review.VideoInfo,
+		Status:       review.Status,
+		CreateAt:     submitAt.Format(time.DateTime),
+	})
+	return &pb.ListReviewRevisionsReply{ReviewID: review.ReviewID, List: list}, nil
+}
+
+// toReviewRevision 修改历史表的create_at是该版本被修改的时间，submitAt是该版本的提交时间
+func toReviewRevision(v *model.ReviewRevision, submitAt time.Time) *pb.ReviewRevision {
+	return &pb.ReviewRevision{
+		Revision:     v.Revision,
+		Content:      v.Content,
+		Score:        v.Score,
+		ServiceScore: v.ServiceScore,
+		ExpressScore: v.ExpressScore,
+		PicInfo:      v.PicInfo,
+		VideoInfo:    v.VideoInfo,
+		Status:       v.Status,
+		CreateAt:     submitAt.Format(time.DateTime),
+	}
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	pb "review-service/api/review/v1"
+)
+
+// GetStoreSummary 查询店铺评分汇总
+func (s *ReviewService) GetStoreSummary(ctx context.Context, req *pb.GetStoreSummaryRequest) (*pb.GetStoreSummaryReply, error) {
+	fmt.Printf("[service] GetStoreSummary req:%#v\n", req)
+	summary, err := s.uc.GetStoreSummary(ctx, req.GetStoreID())
+	if err != nil {
+		return nil, err
+	}
+	distribution := make([]*pb.StarCount, 0, len(summary.StarCounts))
+	for i, count := range summary.StarCounts {
+		distribution = append(distribution, &pb.StarCount{Star: int32(i + 1), Count: count})
+	}
+	return &pb.GetStoreSummaryReply{
+		StoreID:         summary.StoreID,
+		ReviewCount:     summary.ReviewCount,
+		AvgScore:        summary.AvgScore,
+		AvgServiceScore: summary.AvgServiceScore,
+		AvgExpressScore: summary.AvgExpressScore,
+		Distribution:    distribution,
+		MediaRatio:      summary.MediaRatio,
+		ReplyRatio:      summary.ReplyRatio,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "review-service/api/review/v1"
+)
+
+// GetReviewTimeline O端查询评价的完整操作记录
+func (s *ReviewService) GetReviewTimeline(ctx context.Context, req *pb.GetReviewTimelineRequest) (*pb.GetReviewTimelineReply, error) {
+	fmt.Printf("[service] GetReviewTimeline req:%#v\n", req)
+	logs, err := s.uc.GetReviewTimeline(ctx, req.GetReviewID())
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*pb.OperationLog, 0, len(logs))
+	for _, v := range logs {
+		list = append(list, &pb.OperationLog{
+			TargetType:   v.TargetType,
+			TargetID:     v.TargetID,
+			Action:       v.Action,
+			Actor:        v.Actor,
+			Role:         v.Role,
+			BeforeStatus: v.BeforeStatus,
+			AfterStatus:  v.AfterStatus,
+			Reason:       v.Reason,
+			Remarks:      v.Remarks,
+			TraceID:      v.TraceID,
+			MetaJSON:     v.MetaJSON,
+			CreateAt:     v.CreateAt.Format(time.DateTime),
+		})
+	}
+	return &pb.GetReviewTimelineReply{ReviewID: req.GetReviewID(), List: list}, nil
+}
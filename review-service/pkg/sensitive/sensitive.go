@@ -0,0 +1,202 @@
+package sensitive
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// 敏感词过滤
+// 基于Aho-Corasick自动机实现多模式匹配，词库文件变更后自动重新加载
+
+// Level 敏感词等级
+type Level int
+
+const (
+	LevelReview Level = 1 // 疑似违规，需要人工审核
+	LevelBlock  Level = 2 // 明确违规，直接拒绝
+)
+
+var EmptyPathErr = errors.New("sensitive初始化失败，词库路径不能为空")
+
+// Hit 命中的敏感词
+type Hit struct {
+	Word  string
+	Level Level
+}
+
+type node struct {
+	children map[rune]*node
+	fail     *node
+	// 以当前节点结尾的敏感词，包括通过fail指针能到达的后缀词
+	outputs []Hit
+}
+
+// automaton AC自动机，构建完成后只读，可以并发使用
+type automaton struct {
+	root *node
+}
+
+func newAutomaton(words map[string]Level) *automaton {
+	root := &node{children: make(map[rune]*node)}
+	// 1.构建trie树
+	for word, level := range words {
+		cur := root
+		for _, r := range word {
+			next, ok := cur.children[r]
+			if !ok {
+				next = &node{children: make(map[rune]*node)}
+				cur.children[r] = next
+			}
+			cur = next
+		}
+		cur.outputs = append(cur.outputs, Hit{Word: word, Level: level})
+	}
+	// 2.按层遍历构建fail指针
+	queue := make([]*node, 0, len(root.children))
+	for _, child := range root.children {
+		child.fail = root
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for r, child := range cur.children {
+			fail := cur.fail
+			for fail != nil && fail.children[r] == nil {
+				fail = fail.fail
+			}
+			if fail == nil {
+				child.fail = root
+			} else {
+				child.fail = fail.children[r]
+			}
+			child.outputs = append(child.outputs, child.fail.outputs...)
+			queue = append(queue, child)
+		}
+	}
+	return &automaton{root: root}
+}
+
+// match 返回文本中命中的所有敏感词，同一个词只返回一次
+func (a *automaton) match(text string) []Hit {
+	var hits []Hit
+	seen := make(map[string]bool)
+	cur := a.root
+	for _, r := range strings.ToLower(text) {
+		for cur != a.root && cur.children[r] == nil {
+			cur = cur.fail
+		}
+		if next, ok := cur.children[r]; ok {
+			cur = next
+		}
+		for _, hit := range cur.outputs {
+			if !seen[hit.Word] {
+				seen[hit.Word] = true
+				hits = append(hits, hit)
+			}
+		}
+	}
+	return hits
+}
+
+// Filter 敏感词过滤器
+type Filter struct {
+	path    string
+	modTime atomic.Int64 // 已加载词库文件的修改时间（UnixNano），Reload和watch会并发读写
+	ac      atomic.Pointer[automaton]
+	stop    chan struct{}
+}
+
+// NewFilter 从词库文件创建过滤器，interval大于0时按间隔检查文件是否变更并重新加载
+// 词库文件每行一个词，格式为 词语[,block]，带block的为明确违规词，其余为疑似违规词，#开头为注释
+func NewFilter(path string, interval time.Duration) (*Filter, error) {
+	if len(path) == 0 {
+		return nil, EmptyPathErr
+	}
+	f := &Filter{path: path, stop: make(chan struct{})}
+	if err := f.Reload(); err != nil {
+		return nil, err
+	}
+	if interval > 0 {
+		go f.watch(interval)
+	}
+	return f, nil
+}
+
+// Match 返回文本中命中的敏感词
+func (f *Filter) Match(text string) []Hit {
+	return f.ac.Load().match(text)
+}
+
+// Reload 重新加载词库，加载失败时继续使用旧的词库
+func (f *Filter) Reload() error {
+	fi, err := os.Stat(f.path)
+	if err != nil {
+		return err
+	}
+	words, err := load(f.path)
+	if err != nil {
+		return err
+	}
+	f.ac.Store(newAutomaton(words))
+	f.modTime.Store(fi.ModTime().UnixNano())
+	return nil
+}
+
+// Close 停止词库文件监听
+func (f *Filter) Close() {
+	close(f.stop)
+}
+
+func (f *Filter) watch(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-f.stop:
+			return
+		case <-ticker.C:
+			fi, err := os.Stat(f.path)
+			if err != nil || fi.ModTime().UnixNano() <= f.modTime.Load() {
+				continue
+			}
+			_ = f.Reload()
+		}
+	}
+}
+
+func load(path string) (map[string]Level, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	words := make(map[string]Level)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		word, level := line, LevelReview
+		if i := strings.LastIndex(line, ","); i >= 0 {
+			word = strings.TrimSpace(line[:i])
+			if strings.TrimSpace(line[i+1:]) == "block" {
+				level = LevelBlock
+			}
+		}
+		if len(word) == 0 {
+			continue
+		}
+		word = strings.ToLower(word)
+		// 同一个词配置了多次，以最高等级为准
+		if words[word] < level {
+			words[word] = level
+		}
+	}
+	return words, scanner.Err()
+}
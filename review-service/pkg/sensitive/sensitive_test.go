@@ -0,0 +1,139 @@
+package sensitive
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+func newTestFilter(t *testing.T, content string) *Filter {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := NewFilter(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func hitMap(hits []Hit) map[string]Level {
+	m := make(map[string]Level, len(hits))
+	for _, h := range hits {
+		m[h.Word] = h.Level
+	}
+	return m
+}
+
+func TestFilterMatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		words string
+		text  string
+		want  map[string]Level
+	}{
+		{
+			name:  "no hit",
+			words: "赌博\n",
+			text:  "这家店的东西很好",
+			want:  map[string]Level{},
+		},
+		{
+			name:  "overlapping words",
+			words: "he\nshe\nhis\nhers\n",
+			text:  "ushers",
+			want:  map[string]Level{"she": LevelReview, "he": LevelReview, "hers": LevelReview},
+		},
+		{
+			name:  "nested words",
+			words: "刷单\n刷单返现,block\n",
+			text:  "联系我刷单返现",
+			want:  map[string]Level{"刷单": LevelReview, "刷单返现": LevelBlock},
+		},
+		{
+			name:  "fail link after mismatch",
+			words: "abcd\nbce\n",
+			text:  "xabce",
+			want:  map[string]Level{"bce": LevelReview},
+		},
+		{
+			name:  "fail link to suffix word",
+			words: "abcd\ncd\n",
+			text:  "abcd",
+			want:  map[string]Level{"abcd": LevelReview, "cd": LevelReview},
+		},
+		{
+			name:  "case folding",
+			words: "SPAM,block\nVx\n",
+			text:  "加vX领取Spam",
+			want:  map[string]Level{"spam": LevelBlock, "vx": LevelReview},
+		},
+		{
+			name:  "repeated word reported once",
+			words: "差评\n",
+			text:  "差评差评差评",
+			want:  map[string]Level{"差评": LevelReview},
+		},
+		{
+			name:  "highest level wins and comments are skipped",
+			words: "# 注释\n假货\n假货,block\n",
+			text:  "卖假货",
+			want:  map[string]Level{"假货": LevelBlock},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestFilter(t, tt.words)
+			got := hitMap(f.Match(tt.text))
+			if len(got) != len(tt.want) {
+				t.Fatalf("Match(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+			for w, level := range tt.want {
+				if got[w] != level {
+					t.Fatalf("Match(%q) = %v, want %v", tt.text, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterReload(t *testing.T) {
+	f := newTestFilter(t, "旧词\n")
+	if err := os.WriteFile(f.path, []byte("新词\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	var words []string
+	for _, h := range f.Match("旧词和新词") {
+		words = append(words, h.Word)
+	}
+	sort.Strings(words)
+	if len(words) != 1 || words[0] != "新词" {
+		t.Fatalf("Match after reload = %v, want [新词]", words)
+	}
+}
+
+// TestFilterWatchReload 手动Reload和后台监听并发读写词库修改时间，配合go test -race检查数据竞争
+func TestFilterWatchReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(path, []byte("旧词\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := NewFilter(path, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	for i := 0; i < 20; i++ {
+		if err := f.Reload(); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
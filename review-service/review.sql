@@ -35,8 +35,9 @@ CREATE TABLE review_info (
                              PRIMARY KEY (`id`),
                              KEY `idx_delete_at` (`delete_at`) COMMENT '逻辑删除索引',
                              KEY `idx_review_id` (`review_id`) COMMENT '评价id索引',
-                             KEY `idx_order_id` (`order_id`) COMMENT '订单id索引',
-                             KEY `idx_user_id` (`user_id`) COMMENT '用户id索引'
+                             UNIQUE KEY `uk_order_sku` (`order_id`,`sku_id`) COMMENT '订单商品唯一索引，一个订单中的每个商品只能评价一次，评价删除后也不能再次评价',
+                             KEY `idx_user_id` (`user_id`) COMMENT '用户id索引',
+                             KEY `idx_status_create_at` (`status`, `create_at`) COMMENT '待审核队列索引'
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='评价表';
 
 
@@ -61,7 +62,7 @@ CREATE TABLE review_reply_info (
                                    PRIMARY KEY (`id`),
                                    KEY `idx_delete_at` (`delete_at`) COMMENT '逻辑删除索引',
                                    KEY `idx_reply_id` (`reply_id`) COMMENT '回复id索引',
-                                   KEY `idx_review_id` (`review_id`) COMMENT '评价id索引',
+                                   UNIQUE KEY `uk_review_id` (`review_id`) COMMENT '评价id唯一索引，一条评价只能回复一次',
                                    KEY `idx_store_id` (`store_id`) COMMENT '店铺id索引'
 )ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='评价商家回复表';
 
@@ -77,12 +78,16 @@ CREATE TABLE review_appeal_info (
                                     `appeal_id` bigint(32) NOT NULL DEFAULT '0' COMMENT '回复id',
                                     `review_id` bigint(32) NOT NULL DEFAULT '0' COMMENT '评价id',
                                     `store_id` bigint(32) NOT NULL DEFAULT '0' COMMENT '店铺id',
-                                    `status` tinyint(4) NOT NULL DEFAULT '10' COMMENT '状态:10待审核；20申诉通过；30申诉驳回',
+                                    `status` tinyint(4) NOT NULL DEFAULT '10' COMMENT '状态:10待审核；20申诉通过；30申诉驳回；40商家撤回；50超时关闭',
                                     `reason` varchar(255) NOT NULL COMMENT '申诉原因类别',
                                     `content` varchar(255) NOT NULL COMMENT '申诉内容描述',
                                     `pic_info` varchar(1024) NOT NULL DEFAULT '' COMMENT '媒体信息：图片',
                                     `video_info` varchar(1024) NOT NULL DEFAULT '' COMMENT '媒体信息：视频',
+                                    `submit_count` int(10) unsigned NOT NULL DEFAULT '1' COMMENT '第几次提交申诉',
+                                    `deadline_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '审核截止时间',
+                                    `escalate_at` timestamp NULL DEFAULT NULL COMMENT '超时升级到高级审核队列的时间',
 
+                                    `op_reason` varchar(512) NOT NULL DEFAULT '' COMMENT '运营审核原因',
                                     `op_remarks` varchar(512) NOT NULL DEFAULT '' COMMENT '运营备注',
                                     `op_user` varchar(64) NOT NULL DEFAULT '' COMMENT '运营者标识',
 
@@ -92,5 +97,140 @@ CREATE TABLE review_appeal_info (
                                     KEY `idx_delete_at` (`delete_at`) COMMENT '逻辑删除索引',
                                     KEY `idx_appeal_id` (`appeal_id`) COMMENT '申诉id索引',
                                     KEY `idx_review_id` (`review_id`) COMMENT '评价id索引',
-                                    KEY `idx_store_id` (`store_id`) COMMENT '店铺id索引'
-)ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='评价商家申诉表';
\ No newline at end of file
+                                    KEY `idx_store_id` (`store_id`) COMMENT '店铺id索引',
+                                    KEY `idx_status_deadline_at` (`status`, `deadline_at`) COMMENT '超时申诉扫描索引'
+)ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='评价商家申诉表';
+CREATE TABLE review_follow_up_info (
+                                       `id` bigint(32) unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',
+                                       `create_by` varchar(48) NOT NULL DEFAULT '' COMMENT '创建方标识',
+                                       `update_by` varchar(48) NOT NULL DEFAULT '' COMMENT '更新方标识',
+                                       `create_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+                                       `update_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+                                       `delete_at` timestamp COMMENT '逻辑删除标记',
+                                       `version` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '乐观锁标记',
+
+                                       `follow_up_id` bigint(32) NOT NULL DEFAULT '0' COMMENT '追评id',
+                                       `review_id` bigint(32) NOT NULL DEFAULT '0' COMMENT '评价id',
+                                       `user_id` bigint(32) NOT NULL DEFAULT '0' COMMENT '用户id',
+                                       `store_id` bigint(32) NOT NULL DEFAULT '0' COMMENT '店铺id',
+                                       `content` varchar(512) NOT NULL COMMENT '追评内容',
+                                       `has_media` tinyint(4) NOT NULL DEFAULT '0' COMMENT '是否有图或视频',
+                                       `pic_info` varchar(1024) NOT NULL DEFAULT '' COMMENT '媒体信息：图片',
+                                       `video_info` varchar(1024) NOT NULL DEFAULT '' COMMENT '媒体信息：视频',
+                                       `status` tinyint(4) NOT NULL DEFAULT '10' COMMENT '状态:10待审核；20审核通过；30审核不通过；40隐藏',
+                                       `op_reason` varchar(512) NOT NULL DEFAULT '' COMMENT '运营审核拒绝原因',
+                                       `op_remarks` varchar(512) NOT NULL DEFAULT '' COMMENT '运营备注',
+                                       `op_user` varchar(64) NOT NULL DEFAULT '' COMMENT '运营者标识',
+
+                                       `ext_json` varchar(1024) NOT NULL DEFAULT '' COMMENT '信息扩展',
+                                       `ctrl_json` varchar(1024) NOT NULL DEFAULT '' COMMENT '控制扩展',
+                                       PRIMARY KEY (`id`),
+                                       KEY `idx_delete_at` (`delete_at`) COMMENT '逻辑删除索引',
+                                       KEY `idx_follow_up_id` (`follow_up_id`) COMMENT '追评id索引',
+                                       UNIQUE KEY `uk_review_id` (`review_id`) COMMENT '评价id唯一索引，一条评价只能追评一次',
+                                       KEY `idx_user_id` (`user_id`) COMMENT '用户id索引'
+)ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='评价追评表';
+
+CREATE TABLE review_store_summary (
+                                      `id` bigint(32) unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',
+                                      `create_by` varchar(48) NOT NULL DEFAULT '' COMMENT '创建方标识',
+                                      `update_by` varchar(48) NOT NULL DEFAULT '' COMMENT '更新方标识',
+                                      `create_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+                                      `update_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+                                      `delete_at` timestamp COMMENT '逻辑删除标记',
+                                      `version` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '乐观锁标记',
+
+                                      `store_id` bigint(32) NOT NULL DEFAULT '0' COMMENT '店铺id',
+                                      `review_count` int(10) NOT NULL DEFAULT '0' COMMENT '审核通过的评价数',
+                                      `score_sum` bigint(32) NOT NULL DEFAULT '0' COMMENT '评分总和',
+                                      `service_score_sum` bigint(32) NOT NULL DEFAULT '0' COMMENT '商家服务评分总和',
+                                      `express_score_sum` bigint(32) NOT NULL DEFAULT '0' COMMENT '物流评分总和',
+                                      `star_1_count` int(10) NOT NULL DEFAULT '0' COMMENT '1星评价数',
+                                      `star_2_count` int(10) NOT NULL DEFAULT '0' COMMENT '2星评价数',
+                                      `star_3_count` int(10) NOT NULL DEFAULT '0' COMMENT '3星评价数',
+                                      `star_4_count` int(10) NOT NULL DEFAULT '0' COMMENT '4星评价数',
+                                      `star_5_count` int(10) NOT NULL DEFAULT '0' COMMENT '5星评价数',
+                                      `media_count` int(10) NOT NULL DEFAULT '0' COMMENT '有图或视频的评价数',
+                                      `reply_count` int(10) NOT NULL DEFAULT '0' COMMENT '商家已回复的评价数',
+
+                                      `ext_json` varchar(1024) NOT NULL DEFAULT '' COMMENT '信息扩展',
+                                      `ctrl_json` varchar(1024) NOT NULL DEFAULT '' COMMENT '控制扩展',
+                                      PRIMARY KEY (`id`),
+                                      KEY `idx_delete_at` (`delete_at`) COMMENT '逻辑删除索引',
+                                      UNIQUE KEY `uk_store_id` (`store_id`) COMMENT '店铺id唯一索引'
+)ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='店铺评分汇总表';
+
+CREATE TABLE review_reply_history (
+                                      `id` bigint(32) unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',
+                                      `create_by` varchar(48) NOT NULL DEFAULT '' COMMENT '创建方标识',
+                                      `update_by` varchar(48) NOT NULL DEFAULT '' COMMENT '更新方标识',
+                                      `create_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+                                      `update_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+                                      `delete_at` timestamp COMMENT '逻辑删除标记',
+                                      `version` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '乐观锁标记',
+
+                                      `reply_id` bigint(32) NOT NULL DEFAULT '0' COMMENT '回复id',
+                                      `review_id` bigint(32) NOT NULL DEFAULT '0' COMMENT '评价id',
+                                      `store_id` bigint(32) NOT NULL DEFAULT '0' COMMENT '店铺id',
+                                      `action` tinyint(4) NOT NULL DEFAULT '0' COMMENT '操作:1修改；2撤回',
+                                      `reply_version` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '被修改或撤回时回复的版本号',
+                                      `content` varchar(512) NOT NULL COMMENT '回复内容',
+                                      `pic_info` varchar(1024) NOT NULL DEFAULT '' COMMENT '媒体信息：图片',
+                                      `video_info` varchar(1024) NOT NULL DEFAULT '' COMMENT '媒体信息：视频',
+                                      `reply_create_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '回复创建时间',
+
+                                      `ext_json` varchar(1024) NOT NULL DEFAULT '' COMMENT '信息扩展',
+                                      `ctrl_json` varchar(1024) NOT NULL DEFAULT '' COMMENT '控制扩展',
+                                      PRIMARY KEY (`id`),
+                                      KEY `idx_delete_at` (`delete_at`) COMMENT '逻辑删除索引',
+                                      KEY `idx_reply_id` (`reply_id`) COMMENT '回复id索引',
+                                      KEY `idx_review_id` (`review_id`) COMMENT '评价id索引'
+)ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='评价回复历史表';
+
+CREATE TABLE review_revision (
+                                 `id` bigint(32) unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',
+                                 `create_by` varchar(48) NOT NULL DEFAULT '' COMMENT '创建方标识',
+                                 `update_by` varchar(48) NOT NULL DEFAULT '' COMMENT '更新方标识',
+                                 `create_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+                                 `update_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+                                 `delete_at` timestamp COMMENT '逻辑删除标记',
+                                 `version` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '乐观锁标记',
+
+                                 `review_id` bigint(32) NOT NULL DEFAULT '0' COMMENT '评价id',
+                                 `user_id` bigint(32) NOT NULL DEFAULT '0' COMMENT '用户id',
+                                 `revision` int(10) unsigned NOT NULL DEFAULT '0' COMMENT '被修改时评价的版本号',
+                                 `content` varchar(512) NOT NULL COMMENT '评价内容',
+                                 `score` tinyint(4) NOT NULL DEFAULT '0' COMMENT '评分',
+                                 `service_score` tinyint(4) NOT NULL DEFAULT '0' COMMENT '商家服务评分',
+                                 `express_score` tinyint(4) NOT NULL DEFAULT '0' COMMENT '物流评分',
+                                 `has_media` tinyint(4) NOT NULL DEFAULT '0' COMMENT '是否有图或视频',
+                                 `pic_info` varchar(1024) NOT NULL DEFAULT '' COMMENT '媒体信息：图片',
+                                 `video_info` varchar(1024) NOT NULL DEFAULT '' COMMENT '媒体信息：视频',
+                                 `status` tinyint(4) NOT NULL DEFAULT '10' COMMENT '被修改时评价的状态:10待审核；20审核通过；30审核不通过；40隐藏',
+
+                                 `ext_json` varchar(1024) NOT NULL DEFAULT '' COMMENT '信息扩展',
+                                 `ctrl_json` varchar(1024) NOT NULL DEFAULT '' COMMENT '控制扩展',
+                                 PRIMARY KEY (`id`),
+                                 KEY `idx_delete_at` (`delete_at`) COMMENT '逻辑删除索引',
+                                 KEY `idx_review_id` (`review_id`) COMMENT '评价id索引'
+)ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='评价修改历史表';
+
+CREATE TABLE review_operation_log (
+                                      `id` bigint(32) unsigned NOT NULL AUTO_INCREMENT COMMENT '主键',
+                                      `create_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+
+                                      `review_id` bigint(32) NOT NULL DEFAULT '0' COMMENT '评价id',
+                                      `target_type` tinyint(4) NOT NULL DEFAULT '0' COMMENT '操作对象类型:1评价；2回复；3申诉；4追评',
+                                      `target_id` bigint(32) NOT NULL DEFAULT '0' COMMENT '操作对象id',
+                                      `action` tinyint(4) NOT NULL DEFAULT '0' COMMENT '操作类型:1创建；2审核；3修改；4删除；5撤回；6超时升级；7超时关闭；8隐藏',
+                                      `actor` varchar(64) NOT NULL DEFAULT '' COMMENT '操作人标识',
+                                      `role` tinyint(4) NOT NULL DEFAULT '0' COMMENT '操作人角色:1用户；2商家；3运营；4系统',
+                                      `before_status` tinyint(4) NOT NULL DEFAULT '0' COMMENT '操作前状态',
+                                      `after_status` tinyint(4) NOT NULL DEFAULT '0' COMMENT '操作后状态',
+                                      `reason` varchar(512) NOT NULL DEFAULT '' COMMENT '操作原因',
+                                      `remarks` varchar(512) NOT NULL DEFAULT '' COMMENT '操作备注',
+                                      `trace_id` varchar(64) NOT NULL DEFAULT '' COMMENT '链路追踪id',
+                                      `meta_json` varchar(1024) NOT NULL DEFAULT '' COMMENT '请求元数据：接口、客户端ip、user-agent等',
+                                      PRIMARY KEY (`id`),
+                                      KEY `idx_review_id` (`review_id`) COMMENT '评价id索引'
+)ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='评价操作日志表，只追加不修改';
@@ -14,11 +14,15 @@ create table if not exists review_appeal_info
     appeal_id  bigint        default 0                 not null comment '回复id',
     review_id  bigint        default 0                 not null comment '评价id',
     store_id   bigint        default 0                 not null comment '店铺id',
-    status     tinyint       default 10                not null comment '状态:10待审核；20申诉通过；30申诉驳回',
+    status     tinyint       default 10                not null comment '状态:10待审核；20申诉通过；30申诉驳回；40商家撤回；50超时关闭',
     reason     varchar(255)                            not null comment '申诉原因类别',
     content    varchar(255)                            not null comment '申诉内容描述',
     pic_info   varchar(1024) default ''                not null comment '媒体信息：图片',
     video_info varchar(1024) default ''                not null comment '媒体信息：视频',
+    submit_count int unsigned default '1'              not null comment '第几次提交申诉',
+    deadline_at timestamp    default CURRENT_TIMESTAMP not null comment '审核截止时间',
+    escalate_at timestamp                              null comment '超时升级到高级审核队列的时间',
+    op_reason  varchar(512)  default ''                not null comment '运营审核原因',
     op_remarks varchar(512)  default ''                not null comment '运营备注',
     op_user    varchar(64)   default ''                not null comment '运营者标识',
     ext_json   varchar(1024) default ''                not null comment '信息扩展',
@@ -51,6 +55,64 @@ create index idx_store_id
 
 -- comment on index idx_store_id not supported: 店铺id索引
 
+create index idx_status_deadline_at
+    on review_appeal_info (status, deadline_at)
+    comment '超时申诉扫描索引';
+
+-- comment on index idx_status_deadline_at not supported: 超时申诉扫描索引
+
+create table if not exists review_follow_up_info
+(
+    id           bigint unsigned auto_increment comment '主键'
+    primary key,
+    create_by    varchar(48)   default ''                not null comment '创建方标识',
+    update_by    varchar(48)   default ''                not null comment '更新方标识',
+    create_at    timestamp     default CURRENT_TIMESTAMP not null comment '创建时间',
+    update_at    timestamp     default CURRENT_TIMESTAMP not null on update CURRENT_TIMESTAMP comment '更新时间',
+    delete_at    timestamp                               null comment '逻辑删除标记',
+    version      int unsigned  default '0'               not null comment '乐观锁标记',
+    follow_up_id bigint        default 0                 not null comment '追评id',
+    review_id    bigint        default 0                 not null comment '评价id',
+    user_id      bigint        default 0                 not null comment '用户id',
+    store_id     bigint        default 0                 not null comment '店铺id',
+    content      varchar(512)                            not null comment '追评内容',
+    has_media    tinyint       default 0                 not null comment '是否有图或视频',
+    pic_info     varchar(1024) default ''                not null comment '媒体信息：图片',
+    video_info   varchar(1024) default ''                not null comment '媒体信息：视频',
+    status       tinyint       default 10                not null comment '状态:10待审核；20审核通过；30审核不通过；40隐藏',
+    op_reason    varchar(512)  default ''                not null comment '运营审核拒绝原因',
+    op_remarks   varchar(512)  default ''                not null comment '运营备注',
+    op_user      varchar(64)   default ''                not null comment '运营者标识',
+    ext_json     varchar(1024) default ''                not null comment '信息扩展',
+    ctrl_json    varchar(1024) default ''                not null comment '控制扩展'
+    )
+    comment '评价追评表' engine = InnoDB
+    charset = utf8mb4;
+
+create index idx_delete_at
+    on review_follow_up_info (delete_at)
+    comment '逻辑删除索引';
+
+-- comment on index idx_delete_at not supported: 逻辑删除索引
+
+create index idx_follow_up_id
+    on review_follow_up_info (follow_up_id)
+    comment '追评id索引';
+
+-- comment on index idx_follow_up_id not supported: 追评id索引
+
+create unique index uk_review_id
+    on review_follow_up_info (review_id)
+    comment '评价id唯一索引，一条评价只能追评一次';
+
+-- comment on index uk_review_id not supported: 评价id唯一索引，一条评价只能追评一次
+
+create index idx_user_id
+    on review_follow_up_info (user_id)
+    comment '用户id索引';
+
+-- comment on index idx_user_id not supported: 用户id索引
+
 create table if not exists review_info
 (
     id              bigint unsigned auto_increment comment '主键'
@@ -95,11 +157,11 @@ create index idx_delete_at
 
 -- comment on index idx_delete_at not supported: 逻辑删除索引
 
-create index idx_order_id
-    on review_info (order_id)
-    comment '订单id索引';
+create unique index uk_order_sku
+    on review_info (order_id, sku_id)
+    comment '订单商品唯一索引，一个订单中的每个商品只能评价一次，评价删除后也不能再次评价';
 
--- comment on index idx_order_id not supported: 订单id索引
+-- comment on index uk_order_sku not supported: 订单商品唯一索引，一个订单中的每个商品只能评价一次，评价删除后也不能再次评价
 
 create index idx_review_id
     on review_info (review_id)
@@ -113,6 +175,82 @@ create index idx_user_id
 
 -- comment on index idx_user_id not supported: 用户id索引
 
+create index idx_status_create_at
+    on review_info (status, create_at)
+    comment '待审核队列索引';
+
+-- comment on index idx_status_create_at not supported: 待审核队列索引
+
+create table if not exists review_operation_log
+(
+    id            bigint unsigned auto_increment comment '主键'
+    primary key,
+    create_at     timestamp     default CURRENT_TIMESTAMP not null comment '创建时间',
+    review_id     bigint        default 0                 not null comment '评价id',
+    target_type   tinyint       default 0                 not null comment '操作对象类型:1评价；2回复；3申诉；4追评',
+    target_id     bigint        default 0                 not null comment '操作对象id',
+    action        tinyint       default 0                 not null comment '操作类型:1创建；2审核；3修改；4删除；5撤回；6超时升级；7超时关闭；8隐藏',
+    actor         varchar(64)   default ''                not null comment '操作人标识',
+    role          tinyint       default 0                 not null comment '操作人角色:1用户；2商家；3运营；4系统',
+    before_status tinyint       default 0                 not null comment '操作前状态',
+    after_status  tinyint       default 0                 not null comment '操作后状态',
+    reason        varchar(512)  default ''                not null comment '操作原因',
+    remarks       varchar(512)  default ''                not null comment '操作备注',
+    trace_id      varchar(64)   default ''                not null comment '链路追踪id',
+    meta_json     varchar(1024) default ''                not null comment '请求元数据：接口、客户端ip、user-agent等'
+    )
+    comment '评价操作日志表，只追加不修改' engine = InnoDB
+    charset = utf8mb4;
+
+create index idx_review_id
+    on review_operation_log (review_id)
+    comment '评价id索引';
+
+-- comment on index idx_review_id not supported: 评价id索引
+
+create table if not exists review_reply_history
+(
+    id              bigint unsigned auto_increment comment '主键'
+    primary key,
+    create_by       varchar(48)   default ''                not null comment '创建方标识',
+    update_by       varchar(48)   default ''                not null comment '更新方标识',
+    create_at       timestamp     default CURRENT_TIMESTAMP not null comment '创建时间',
+    update_at       timestamp     default CURRENT_TIMESTAMP not null on update CURRENT_TIMESTAMP comment '更新时间',
+    delete_at       timestamp                               null comment '逻辑删除标记',
+    version         int unsigned  default '0'               not null comment '乐观锁标记',
+    reply_id        bigint        default 0                 not null comment '回复id',
+    review_id       bigint        default 0                 not null comment '评价id',
+    store_id        bigint        default 0                 not null comment '店铺id',
+    action          tinyint       default 0                 not null comment '操作:1修改；2撤回',
+    reply_version   int unsigned  default '0'               not null comment '被修改或撤回时回复的版本号',
+    content         varchar(512)                            not null comment '回复内容',
+    pic_info        varchar(1024) default ''                not null comment '媒体信息：图片',
+    video_info      varchar(1024) default ''                not null comment '媒体信息：视频',
+    reply_create_at timestamp     default CURRENT_TIMESTAMP not null comment '回复创建时间',
+    ext_json        varchar(1024) default ''                not null comment '信息扩展',
+    ctrl_json       varchar(1024) default ''                not null comment '控制扩展'
+    )
+    comment '评价回复历史表' engine = InnoDB
+    charset = utf8mb4;
+
+create index idx_delete_at
+    on review_reply_history (delete_at)
+    comment '逻辑删除索引';
+
+-- comment on index idx_delete_at not supported: 逻辑删除索引
+
+create index idx_reply_id
+    on review_reply_history (reply_id)
+    comment '回复id索引';
+
+-- comment on index idx_reply_id not supported: 回复id索引
+
+create index idx_review_id
+    on review_reply_history (review_id)
+    comment '评价id索引';
+
+-- comment on index idx_review_id not supported: 评价id索引
+
 create table if not exists review_reply_info
 (
     id         bigint unsigned auto_increment comment '主键'
@@ -147,11 +285,11 @@ create index idx_reply_id
 
 -- comment on index idx_reply_id not supported: 回复id索引
 
-create index idx_review_id
+create unique index uk_review_id
     on review_reply_info (review_id)
-    comment '评价id索引';
+    comment '评价id唯一索引，一条评价只能回复一次';
 
--- comment on index idx_review_id not supported: 评价id索引
+-- comment on index uk_review_id not supported: 评价id唯一索引，一条评价只能回复一次
 
 create index idx_store_id
     on review_reply_info (store_id)
@@ -159,3 +297,82 @@ create index idx_store_id
 
 -- comment on index idx_store_id not supported: 店铺id索引
 
+create table if not exists review_revision
+(
+    id            bigint unsigned auto_increment comment '主键'
+    primary key,
+    create_by     varchar(48)   default ''                not null comment '创建方标识',
+    update_by     varchar(48)   default ''                not null comment '更新方标识',
+    create_at     timestamp     default CURRENT_TIMESTAMP not null comment '创建时间',
+    update_at     timestamp     default CURRENT_TIMESTAMP not null on update CURRENT_TIMESTAMP comment '更新时间',
+    delete_at     timestamp                               null comment '逻辑删除标记',
+    version       int unsigned  default '0'               not null comment '乐观锁标记',
+    review_id     bigint        default 0                 not null comment '评价id',
+    user_id       bigint        default 0                 not null comment '用户id',
+    revision      int unsigned  default '0'               not null comment '被修改时评价的版本号',
+    content       varchar(512)                            not null comment '评价内容',
+    score         tinyint       default 0                 not null comment '评分',
+    service_score tinyint       default 0                 not null comment '商家服务评分',
+    express_score tinyint       default 0                 not null comment '物流评分',
+    has_media     tinyint       default 0                 not null comment '是否有图或视频',
+    pic_info      varchar(1024) default ''                not null comment '媒体信息：图片',
+    video_info    varchar(1024) default ''                not null comment '媒体信息：视频',
+    status        tinyint       default 10                not null comment '被修改时评价的状态:10待审核；20审核通过；30审核不通过；40隐藏',
+    ext_json      varchar(1024) default ''                not null comment '信息扩展',
+    ctrl_json     varchar(1024) default ''                not null comment '控制扩展'
+    )
+    comment '评价修改历史表' engine = InnoDB
+    charset = utf8mb4;
+
+create index idx_delete_at
+    on review_revision (delete_at)
+    comment '逻辑删除索引';
+
+-- comment on index idx_delete_at not supported: 逻辑删除索引
+
+create index idx_review_id
+    on review_revision (review_id)
+    comment '评价id索引';
+
+-- comment on index idx_review_id not supported: 评价id索引
+
+create table if not exists review_store_summary
+(
+    id                bigint unsigned auto_increment comment '主键'
+    primary key,
+    create_by         varchar(48)   default ''                not null comment '创建方标识',
+    update_by         varchar(48)   default ''                not null comment '更新方标识',
+    create_at         timestamp     default CURRENT_TIMESTAMP not null comment '创建时间',
+    update_at         timestamp     default CURRENT_TIMESTAMP not null on update CURRENT_TIMESTAMP comment '更新时间',
+    delete_at         timestamp                               null comment '逻辑删除标记',
+    version           int unsigned  default '0'               not null comment '乐观锁标记',
+    store_id          bigint        default 0                 not null comment '店铺id',
+    review_count      int           default '0'               not null comment '审核通过的评价数',
+    score_sum         bigint        default 0                 not null comment '评分总和',
+    service_score_sum bigint        default 0                 not null comment '商家服务评分总和',
+    express_score_sum bigint        default 0                 not null comment '物流评分总和',
+    star_1_count      int           default '0'               not null comment '1星评价数',
+    star_2_count      int           default '0'               not null comment '2星评价数',
+    star_3_count      int           default '0'               not null comment '3星评价数',
+    star_4_count      int           default '0'               not null comment '4星评价数',
+    star_5_count      int           default '0'               not null comment '5星评价数',
+    media_count       int           default '0'               not null comment '有图或视频的评价数',
+    reply_count       int           default '0'               not null comment '商家已回复的评价数',
+    ext_json          varchar(1024) default ''                not null comment '信息扩展',
+    ctrl_json         varchar(1024) default ''                not null comment '控制扩展'
+    )
+    comment '店铺评分汇总表' engine = InnoDB
+    charset = utf8mb4;
+
+create index idx_delete_at
+    on review_store_summary (delete_at)
+    comment '逻辑删除索引';
+
+-- comment on index idx_delete_at not supported: 逻辑删除索引
+
+create unique index uk_store_id
+    on review_store_summary (store_id)
+    comment '店铺id唯一索引';
+
+-- comment on index uk_store_id not supported: 店铺id唯一索引
+